@@ -0,0 +1,127 @@
+// Copyright 2015 James Cote and Liberty Fund, Inc.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docusign
+
+import (
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+)
+
+// EnvelopeBuilder simplifies creating an envelope with documents,
+// signers and tabs.
+//
+//	env, files := NewEnvelopeBuilder("Please sign").
+//		AddDocument("1", "contract.pdf", f).
+//		AddSigner("signer@example.com", "Signer Name", 1).
+//		SignHere("/sig1/").
+//		Text("/addr1/", "Address", "").
+//		Envelope().
+//		Send().
+//		Build()
+//	res, err := sv.EnvelopeCreate(ctx, env, files...)
+type EnvelopeBuilder struct {
+	env     Envelope
+	files   []*UploadFile
+	signers []*SignerBuilder
+}
+
+// NewEnvelopeBuilder starts a new envelope with the email subject.
+func NewEnvelopeBuilder(subject string) *EnvelopeBuilder {
+	return &EnvelopeBuilder{env: Envelope{EmailSubject: subject}}
+}
+
+// EmailBlurb sets the email message of the envelope.
+func (b *EnvelopeBuilder) EmailBlurb(blurb string) *EnvelopeBuilder {
+	b.env.EmailBlurb = blurb
+	return b
+}
+
+// Send causes the envelope to be sent when created.  Otherwise
+// the envelope is saved as a draft.
+func (b *EnvelopeBuilder) Send() *EnvelopeBuilder {
+	b.env.Status = StatusSent
+	return b
+}
+
+// AddDocument adds a document read from r.  The content type is determined
+// by the extension of name, defaulting to application/pdf.
+func (b *EnvelopeBuilder) AddDocument(id, name string, r io.Reader) *EnvelopeBuilder {
+	ct := mime.TypeByExtension(filepath.Ext(name))
+	if ct == "" {
+		ct = "application/pdf"
+	}
+	order := strconv.Itoa(len(b.files) + 1)
+	b.env.Documents = append(b.env.Documents, Document{Name: name, DocumentId: id, Order: order})
+	b.files = append(b.files, &UploadFile{ContentType: ct, FileName: name, Id: id, Order: order, Data: r})
+	return b
+}
+
+// AddCustomField adds a text custom field to the envelope.
+func (b *EnvelopeBuilder) AddCustomField(name, value string) *EnvelopeBuilder {
+	if b.env.CustomFields == nil {
+		b.env.CustomFields = &CustomFieldList{}
+	}
+	b.env.CustomFields.TextCustomFields = append(b.env.CustomFields.TextCustomFields, CustomField{Name: name, Value: value})
+	return b
+}
+
+// AddSigner adds a signer with the routing order.  Recipient ids are
+// assigned in the order signers are added.
+func (b *EnvelopeBuilder) AddSigner(email, name string, order int) *SignerBuilder {
+	sb := &SignerBuilder{eb: b}
+	sb.signer.Email = email
+	sb.signer.Name = name
+	sb.signer.RoutingOrder = strconv.Itoa(order)
+	sb.signer.RecipientId = strconv.Itoa(len(b.signers) + 1)
+	sb.signer.Tabs = &Tabs{}
+	b.signers = append(b.signers, sb)
+	return sb
+}
+
+// Build returns the envelope and the files to pass to EnvelopeCreate.
+func (b *EnvelopeBuilder) Build() (*Envelope, []*UploadFile) {
+	env := b.env
+	if len(b.signers) > 0 {
+		env.Recipients = &RecipientList{Signers: make([]Signer, len(b.signers))}
+		for i, sb := range b.signers {
+			env.Recipients.Signers[i] = sb.signer
+		}
+	}
+	return &env, b.files
+}
+
+// SignerBuilder adds tabs to a signer created by EnvelopeBuilder.AddSigner.
+type SignerBuilder struct {
+	eb     *EnvelopeBuilder
+	signer Signer
+}
+
+// ClientUserId marks the signer as an embedded signer.
+func (sb *SignerBuilder) ClientUserId(id string) *SignerBuilder {
+	sb.signer.ClientUserId = id
+	return sb
+}
+
+// SignHere adds a signature tab at each occurrence of anchor.
+func (sb *SignerBuilder) SignHere(anchor string) *SignerBuilder {
+	tab := SignHere(sb.signer.RecipientId, "", anchor)
+	sb.signer.Tabs.SignHereTabs = append(sb.signer.Tabs.SignHereTabs, tab)
+	return sb
+}
+
+// Text adds a text tab with label and value at each occurrence of anchor.
+func (sb *SignerBuilder) Text(anchor, label, value string) *SignerBuilder {
+	tab := Text(sb.signer.RecipientId, "", anchor, label, value)
+	sb.signer.Tabs.TextTabs = append(sb.signer.Tabs.TextTabs, tab)
+	return sb
+}
+
+// Envelope returns the EnvelopeBuilder to continue building the envelope.
+func (sb *SignerBuilder) Envelope() *EnvelopeBuilder {
+	return sb.eb
+}
@@ -6,11 +6,17 @@
 package docusign
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
@@ -63,7 +69,7 @@ func (s *Service) FolderEnvSearch(ctx context.Context, folderId string, args ...
 type FolderEnvSearchParam NmVal
 
 func FolderEnvSearchStartPosition(pos int) FolderEnvSearchParam {
-	return FolderEnvSearchParam{Name: "startpostition", Value: strconv.Itoa(pos)}
+	return FolderEnvSearchParam{Name: "start_position", Value: strconv.Itoa(pos)}
 }
 
 func FolderEnvSearchFromDate(tm time.Time) FolderEnvSearchParam {
@@ -78,7 +84,7 @@ func FolderEnvSearchText(searchText string) FolderEnvSearchParam {
 	return FolderEnvSearchParam{Name: "search_text", Value: searchText}
 }
 
-func FolderEnvSearchStatus(status string) FolderEnvSearchParam {
+func FolderEnvSearchStatus(status EnvelopeStatus) FolderEnvSearchParam {
 	return FolderEnvSearchParam{Name: "status", Value: status}
 }
 
@@ -127,7 +133,7 @@ var SearchFolderCompleted SearchFolder = "completed"
 type SearchFolderParam NmVal
 
 func EnvelopeSearchStartPosition(pos int) SearchFolderParam {
-	return SearchFolderParam{Name: "startpostition", Value: strconv.Itoa(pos)}
+	return SearchFolderParam{Name: "start_position", Value: strconv.Itoa(pos)}
 }
 
 func EnvelopeSearchCount(cnt int) SearchFolderParam {
@@ -185,6 +191,91 @@ var EnvelopeSearchIncludeRecipients = SearchFolderParam{
 	Value: "true",
 }
 
+// EnvelopeSearchAll returns an EnvelopePager that walks every page of
+// the EnvelopeSearch results.  A count arg limits the total number of
+// items returned; see EnvelopePager.MaxItems.
+func (s *Service) EnvelopeSearchAll(searchFld SearchFolder, args ...SearchFolderParam) *EnvelopePager {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	return newEnvelopePager(s, fmt.Sprintf("search_folders/%s", searchFld), q)
+}
+
+// FolderEnvSearchAll returns an EnvelopePager that walks every page of
+// the FolderEnvSearch results.  A count arg limits the total number of
+// items returned; see EnvelopePager.MaxItems.
+func (s *Service) FolderEnvSearchAll(folderId string, args ...FolderEnvSearchParam) *EnvelopePager {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	return newEnvelopePager(s, fmt.Sprintf("folders/%s", folderId), q)
+}
+
+// newEnvelopePager returns a pager starting at path with query q.  MaxItems
+// is set from the count of q.
+func newEnvelopePager(s *Service, path string, q url.Values) *EnvelopePager {
+	p := &EnvelopePager{sv: s, next: &url.URL{Path: path, RawQuery: q.Encode()}}
+	if cnt, err := strconv.Atoi(q.Get("count")); err == nil && cnt > 0 {
+		p.MaxItems = cnt
+	}
+	return p
+}
+
+// EnvelopePager retrieves successive pages of a search by following the
+// NextUri of each result.
+type EnvelopePager struct {
+	// MaxItems limits the total number of items returned.  Zero means no
+	// limit.  It is set from the count arg of EnvelopeSearchAll and
+	// FolderEnvSearchAll, which is also sent as the page size.
+	MaxItems int
+
+	sv   *Service
+	next *url.URL
+	cnt  int
+}
+
+// Done returns true when all pages have been retrieved.
+func (p *EnvelopePager) Done() bool {
+	return p.next == nil || (p.MaxItems > 0 && p.cnt >= p.MaxItems)
+}
+
+// Next returns the items of the next page.  Once Done returns true, Next
+// returns nil, nil.
+func (p *EnvelopePager) Next(ctx context.Context) ([]FolderItem, error) {
+	if p.Done() {
+		return nil, nil
+	}
+	u := *p.next
+	var ret *FolderEnvList
+	if err := (&Call{
+		Method: "GET",
+		URL:    &u,
+		Result: &ret,
+	}).Do(ctx, p.sv); err != nil {
+		return nil, err
+	}
+	p.next = nil
+	if ret == nil {
+		return nil, nil
+	}
+	items := ret.FolderItems
+	if p.MaxItems > 0 && p.cnt+len(items) > p.MaxItems {
+		items = items[:p.MaxItems-p.cnt]
+	}
+	p.cnt += len(items)
+	if ret.NextUri != "" && len(items) > 0 {
+		nu, err := url.Parse(ret.NextUri)
+		if err != nil {
+			return items, err
+		}
+		// nextUri is relative to the account
+		p.next = &url.URL{Path: strings.TrimPrefix(nu.Path, "/"), RawQuery: nu.RawQuery}
+	}
+	return items, nil
+}
+
 // EnvelopeAuditEvents returns the events for this envelope.
 //
 // RestApi documentation
@@ -199,6 +290,25 @@ func (s *Service) EnvelopeAuditEvents(ctx context.Context, envId string) (*Audit
 
 }
 
+// CertificateOfCompletion combines the envelope, its recipients and its
+// audit events into a Certificate, a structured alternative to the
+// certificate of completion pdf.
+func (s *Service) CertificateOfCompletion(ctx context.Context, envId string) (*Certificate, error) {
+	env, err := s.EnvelopeGet(ctx, envId)
+	if err != nil {
+		return nil, err
+	}
+	rl, err := s.Recipients(ctx, envId)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.EnvelopeAuditEvents(ctx, envId)
+	if err != nil {
+		return nil, err
+	}
+	return newCertificate(env, rl, events), nil
+}
+
 // EnvelopeNotification returns the reminder and expiration information for the envelope.
 //
 // RestApi documentation
@@ -212,6 +322,134 @@ func (s *Service) EnvelopeNotification(ctx context.Context, envId string) (*Noti
 	}).Do(ctx, s)
 }
 
+// EnvelopeNotificationUpdate changes the reminder and expiration settings for
+// the envelope.
+//
+// RestApi documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Set%20Envelope%20Notification%20Information.htm
+func (s *Service) EnvelopeNotificationUpdate(ctx context.Context, envId string, n *Notification) (*Notification, error) {
+	var ret *Notification
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/notification", envId)},
+		Payload: n,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeWorkflowGet returns the scheduled sending and delayed routing
+// workflow of the envelope.
+//
+// RestApi documentation
+// https://developers.docusign.com/esign-rest-api/reference/Envelopes/Envelopes/getEnvelopeWorkflowDefinition
+func (s *Service) EnvelopeWorkflowGet(ctx context.Context, envId string) (*EnvelopeWorkflow, error) {
+	var ret *EnvelopeWorkflow
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/workflow", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeWorkflowUpdate replaces the workflow of a draft envelope.
+//
+// RestApi documentation
+// https://developers.docusign.com/esign-rest-api/reference/Envelopes/Envelopes/updateEnvelopeWorkflowDefinition
+func (s *Service) EnvelopeWorkflowUpdate(ctx context.Context, envId string, wf *EnvelopeWorkflow) (*EnvelopeWorkflow, error) {
+	var ret *EnvelopeWorkflow
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/workflow", envId)},
+		Payload: wf,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeWorkflowResume releases an envelope paused by a workflow step,
+// continuing routing to the next recipients.  An error is returned without
+// updating the envelope if its workflow is not paused.
+//
+// This does not PUT to envelopes/{envelopeId}/workflow, which replaces the
+// workflow definition.  DocuSign documents unpausing as an envelope update
+// with {"workflow":{"workflowStatus":"in_progress"}} and resend_envelope=true,
+// which is required for routing to continue.  The resend notifies the
+// recipients whose turn it now is; recipients who have already acted are
+// not notified again.
+//
+// RestApi documentation
+// https://developers.docusign.com/esign-rest-api/guides/features/workflow
+// https://developers.docusign.com/docs/esign-rest-api/reference/envelopes/envelopes/update/
+func (s *Service) EnvelopeWorkflowResume(ctx context.Context, envId string) error {
+	wf, err := s.EnvelopeWorkflowGet(ctx, envId)
+	if err != nil {
+		return err
+	}
+	if wf.WorkflowStatus != WorkflowStatusPaused {
+		return fmt.Errorf("docusign: envelope %s workflow is not paused (status %q)", envId, wf.WorkflowStatus)
+	}
+	return (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s", envId), RawQuery: "resend_envelope=true"},
+		Payload: &Envelope{Workflow: &EnvelopeWorkflow{WorkflowStatus: WorkflowStatusInProgress}},
+	}).Do(ctx, s)
+}
+
+// EmailSettings returns the email override settings of an envelope.
+//
+// RestApi documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Email%20Settings.htm
+func (s *Service) EmailSettings(ctx context.Context, envId string) (*EmailSetting, error) {
+	var ret *EmailSetting
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/email_settings", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EmailSettingsCreate adds email override settings to an envelope.
+//
+// RestApi documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Add%20Envelope%20Email%20Settings.htm
+func (s *Service) EmailSettingsCreate(ctx context.Context, envId string, es *EmailSetting) (*EmailSetting, error) {
+	var ret *EmailSetting
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/email_settings", envId)},
+		Payload: es,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EmailSettingsUpdate updates the email override settings of an envelope.  Changes
+// only affect emails sent after the update.
+//
+// RestApi documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Envelope%20Email%20Settings.htm
+func (s *Service) EmailSettingsUpdate(ctx context.Context, envId string, es *EmailSetting) (*EmailSetting, error) {
+	var ret *EmailSetting
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/email_settings", envId)},
+		Payload: es,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EmailSettingsDelete removes the email override settings of an envelope, restoring
+// the account defaults.
+//
+// RestApi documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Delete%20Envelope%20Email%20Settings.htm
+func (s *Service) EmailSettingsDelete(ctx context.Context, envId string) (*EmailSetting, error) {
+	var ret *EmailSetting
+	return ret, (&Call{
+		Method: "DELETE",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/email_settings", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
 // EnvelopeCustomFields returns all custom field info in a Custom Field List
 // RestApi documentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Custom%20Field%20Information.htm
@@ -297,11 +535,33 @@ func (s *Service) EnvelopeDocument(ctx context.Context, envId string, docId stri
 	var ret *http.Response
 	return ret, (&Call{
 		Method: "GET",
-		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents", envId), RawQuery: q.Encode()},
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s", envId, docId), RawQuery: q.Encode()},
 		Result: &ret,
 	}).Do(ctx, s)
 }
 
+// EnvelopeDocumentTo copies the pdf of a specific document from an envelope to w
+// and returns the number of bytes written.  The response is always closed.
+// Only possible arg is show_changes={true/false}
+func (s *Service) EnvelopeDocumentTo(ctx context.Context, envId string, docId string, w io.Writer, args ...EnvelopeDocumentParam) (int64, error) {
+	res, err := s.EnvelopeDocument(ctx, envId, docId, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	return io.Copy(w, res.Body)
+}
+
+// Special document ids for EnvelopeDocument.  DocuSign's combined endpoint does
+// not accept a list of documents, so to archive a subset of an envelope's
+// documents, download each document individually and use DocumentIdCertificate
+// for the certificate of completion.
+const (
+	DocumentIdCombined    = "combined"
+	DocumentIdArchive     = "archive"
+	DocumentIdCertificate = "certificate"
+)
+
 type EnvelopeDocumentParam NmVal
 
 var EnvelopeDocumentShowChanges = EnvelopeDocumentParam{
@@ -318,6 +578,10 @@ var EnvelopeDocumentShowChanges = EnvelopeDocumentParam{
 // when finished processing.
 // Optional additions: certificate={true or false}, show_changes={true}, watermark={true or false}
 //
+// All documents are included; DocuSign does not support selecting a subset.  Use
+// EnvelopeDocument for individual documents, or with DocumentIdCertificate for
+// only the certificate.
+//
 // RestApi Documentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Documents%20and%20Certificate.htm
 func (s *Service) EnvelopeDocumentsCombined(ctx context.Context, envId string, args ...EnvelopeDocumentsCombinedParam) (*http.Response, error) {
@@ -333,6 +597,110 @@ func (s *Service) EnvelopeDocumentsCombined(ctx context.Context, envId string, a
 	}).Do(ctx, s)
 }
 
+// EnvelopeDocumentsCombinedTo copies the combined pdf of all documents to w and
+// returns the number of bytes written.  The response is always closed.
+// Optional additions: certificate={true or false}, show_changes={true}, watermark={true or false}
+func (s *Service) EnvelopeDocumentsCombinedTo(ctx context.Context, envId string, w io.Writer, args ...EnvelopeDocumentsCombinedParam) (int64, error) {
+	res, err := s.EnvelopeDocumentsCombined(ctx, envId, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	return io.Copy(w, res.Body)
+}
+
+// EnvelopeDocumentsCombinedResume copies the combined pdf of all documents to w,
+// starting at w's current offset.  If the download fails with a network error or
+// a retryable status, it resumes from the current offset using a Range request.
+// Up to resumeAttempts attempts are made.
+func (s *Service) EnvelopeDocumentsCombinedResume(ctx context.Context, envId string, w io.WriteSeeker, args ...EnvelopeDocumentsCombinedParam) error {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var err error
+	for attempt := 1; attempt <= resumeAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * resumeBackoff):
+			}
+		}
+		var done bool
+		if done, err = s.combinedRange(ctx, envId, q, w); done || err == nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// resumeAttempts and resumeBackoff control EnvelopeDocumentsCombinedResume retries.
+var (
+	resumeAttempts = 5
+	resumeBackoff  = time.Second
+)
+
+// combinedRange downloads the combined pdf from the current offset of w.  done
+// is true when err should not be retried.
+func (s *Service) combinedRange(ctx context.Context, envId string, q url.Values, w io.WriteSeeker) (done bool, err error) {
+	off, err := w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return true, err
+	}
+	var hdr http.Header
+	if off > 0 {
+		hdr = http.Header{"Range": {fmt.Sprintf("bytes=%d-", off)}}
+	}
+	var res *http.Response
+	err = (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/combined", envId), RawQuery: q.Encode()},
+		Header: hdr,
+		Result: &res,
+	}).Do(ctx, s)
+	if err != nil {
+		if re, ok := err.(*ResponseError); ok {
+			// 416 when off is at the end of the document
+			if re.Status == http.StatusRequestedRangeNotSatisfiable && off > 0 {
+				return true, nil
+			}
+			return !re.IsRetryable(), err
+		}
+		return false, err
+	}
+	defer res.Body.Close()
+	if off > 0 && res.StatusCode != http.StatusPartialContent {
+		// server ignored the range so start over
+		if _, err = w.Seek(0, io.SeekStart); err != nil {
+			return true, err
+		}
+	}
+	tw := &trackWriter{w: w}
+	if _, err = io.Copy(tw, ctxReader{ctx, res.Body}); tw.err != nil {
+		// write errors are not retried
+		return true, tw.err
+	}
+	return false, err
+}
+
+// trackWriter records write errors to distinguish them from read errors.
+type trackWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (t *trackWriter) Write(p []byte) (int, error) {
+	n, err := t.w.Write(p)
+	if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
 type EnvelopeDocumentsCombinedParam NmVal
 
 var EnvelopeDocumentsCombinedCert = EnvelopeDocumentsCombinedParam{
@@ -348,6 +716,138 @@ var EnvelopeDocumentsCombinedWatermark = EnvelopeDocumentsCombinedParam{
 	Value: "true",
 }
 
+// EnvelopeDocumentsCombinedNoCert excludes the certificate of completion,
+// returning only the envelope's documents.
+var EnvelopeDocumentsCombinedNoCert = EnvelopeDocumentsCombinedParam{
+	Name:  "certificate",
+	Value: "false",
+}
+
+// DocumentPages returns the page list of an envelope document.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Page%20Images.htm
+func (s *Service) DocumentPages(ctx context.Context, envId string, docId string) (*PageList, error) {
+	var ret *PageList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s/pages", envId, docId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// DocumentPageImage returns the png image of a document page (numbered from 1).  Any
+// status other than 200 results in a nil response and a ResponseError.  Developer
+// is expected to close the http.Response when finished processing.
+// Optional additions: dpi={int}, max_width={int}, max_height={int}
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Page%20Image.htm
+func (s *Service) DocumentPageImage(ctx context.Context, envId string, docId string, page int, args ...PageImageParam) (*http.Response, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *http.Response
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s/pages/%d/page_image", envId, docId, page), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type PageImageParam NmVal
+
+func PageImageDpi(dpi int) PageImageParam {
+	return PageImageParam{Name: "dpi", Value: strconv.Itoa(dpi)}
+}
+
+func PageImageMaxWidth(width int) PageImageParam {
+	return PageImageParam{Name: "max_width", Value: strconv.Itoa(width)}
+}
+
+func PageImageMaxHeight(height int) PageImageParam {
+	return PageImageParam{Name: "max_height", Value: strconv.Itoa(height)}
+}
+
+// EnvelopeComments returns the comment threads of an envelope.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Comments%20Transcript.htm
+func (s *Service) EnvelopeComments(ctx context.Context, envId string) (*CommentsList, error) {
+	var ret *CommentsList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/comments/transcript", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeCommentsTranscript returns the raw comments transcript (a pdf unless
+// the encoding param requests otherwise) of an envelope.  Developer is expected
+// to close the http.Response when finished processing.
+// Optional additions: encoding={string}
+func (s *Service) EnvelopeCommentsTranscript(ctx context.Context, envId string, args ...CommentsTranscriptParam) (*http.Response, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *http.Response
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/comments/transcript", envId), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type CommentsTranscriptParam NmVal
+
+func CommentsTranscriptEncoding(encoding string) CommentsTranscriptParam {
+	return CommentsTranscriptParam{Name: "encoding", Value: encoding}
+}
+
+// RecipientSignatureImage returns the signature image of a recipient.  If the recipient
+// has not signed, a nil response and a ResponseError with docusign's error message are
+// returned.  Developer is expected to close the http.Response when finished processing.
+// Optional additions: include_chrome={true}
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Signature%20Image%20Information%20for%20a%20Recipient.htm
+func (s *Service) RecipientSignatureImage(ctx context.Context, envId string, recipId string, args ...SignatureImageParam) (*http.Response, error) {
+	return s.recipientImage(ctx, envId, recipId, "signature_image", args)
+}
+
+// RecipientInitialsImage returns the initials image of a recipient.  If the recipient
+// has not initialed, a nil response and a ResponseError with docusign's error message
+// are returned.  Developer is expected to close the http.Response when finished processing.
+// Optional additions: include_chrome={true}
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Initials%20Image%20Information%20for%20a%20Recipient.htm
+func (s *Service) RecipientInitialsImage(ctx context.Context, envId string, recipId string, args ...SignatureImageParam) (*http.Response, error) {
+	return s.recipientImage(ctx, envId, recipId, "initials_image", args)
+}
+
+func (s *Service) recipientImage(ctx context.Context, envId string, recipId string, image string, args []SignatureImageParam) (*http.Response, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *http.Response
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/%s", envId, recipId, image), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type SignatureImageParam NmVal
+
+var SignatureImageIncludeChrome = SignatureImageParam{
+	Name:  "include_chrome",
+	Value: "true",
+}
+
 // LoginInformation determine if a user is authenticated and to choose the account to be used
 // for other operations. Each account associated with the login credentials is listed.
 // optional paramenters:
@@ -391,58 +891,364 @@ var LoginInformationSettingsNone = LoginInfoParam{
 // RestApi Documentation
 //
 func (s *Service) EnvelopeCreate(ctx context.Context, env *Envelope, files ...*UploadFile) (*EnvelopeResponse, error) {
-	var ret *EnvelopeResponse
-	return ret, (&Call{
-		Method:  "POST",
-		URL:     &url.URL{Path: "envelopes"},
-		Payload: env,
-		Result:  &ret,
-		Files:   files,
-	}).Do(ctx, s)
-
+	return s.EnvelopeCreateWithParams(ctx, env, nil, files...)
 }
 
-// EnvelopeStatusChanges returns envelope status changes for all envelopes. The information returned can be
-// modified by adding query strings to limit the request to check between certain dates and times, or for certain envelopes,
-// or for certain status codes. It is recommended that you use one or more of the query strings in order to limit the size of the response.
-//
-// RestApi Documentation
-// https://www.docusign.com/p/RESTAPIGuide/Content/REST API References/Get Envelope Status Changes.htm
-//
-// Optional query strings: from_date={dateTime}, to_date={dateTime}, status={status code}, from_to_status={changed or any or list of statuses},
-// envelopeId={envelopeId}, custom_field={envelope custom field name}={envelope custom field value}, transaction_ids={transactionIds (comma separated)}
+// EnvelopeCreateWithParams adds an envelope as EnvelopeCreate, adding args to
+// the query string.
+// Optional additions: merge_roles_on_draft={true}, change_routing_order={true}
 //
-// Use DsQueryTimeFormat to formate dateTime query arguments
-func (s *Service) EnvelopeStatusChanges(ctx context.Context, args ...EnvelopeStatusChangesParam) (*EnvelopeList, error) {
+//	res, err := sv.EnvelopeCreateWithParams(ctx, env, []docusign.EnvelopeCreateParam{docusign.EnvelopeCreateMergeRolesOnDraft})
+func (s *Service) EnvelopeCreateWithParams(ctx context.Context, env *Envelope, args []EnvelopeCreateParam, files ...*UploadFile) (*EnvelopeResponse, error) {
+	if err := env.validateDelivery(); err != nil {
+		return nil, err
+	}
+	files, err := documentFiles(env.Documents, files)
+	if err != nil {
+		return nil, err
+	}
 	q := make(url.Values)
 	for _, nv := range args {
 		q.Add(nv.Name, nv.Value)
 	}
-	var ret *EnvelopeList
+	var ret *EnvelopeResponse
 	return ret, (&Call{
-		Method: "GET",
-		URL:    &url.URL{Path: "envelopes", RawQuery: q.Encode()},
-		Result: &ret,
+		Method:  "POST",
+		URL:     &url.URL{Path: "envelopes", RawQuery: q.Encode()},
+		Payload: env,
+		Result:  &ret,
+		Files:   files,
 	}).Do(ctx, s)
+
 }
 
-type EnvelopeStatusChangesParam NmVal
+type EnvelopeCreateParam NmVal
 
-func StatusChangeFromDate(t time.Time) EnvelopeStatusChangesParam {
-	return EnvelopeStatusChangesParam{Name: "from_date", Value: DsQueryTimeFormat(t)}
+// EnvelopeCreateMergeRolesOnDraft merges template roles and recipients
+// with the same role name when creating a draft from composite templates.
+var EnvelopeCreateMergeRolesOnDraft = EnvelopeCreateParam{
+	Name:  "merge_roles_on_draft",
+	Value: "true",
 }
 
-func StatusChangeToDate(t time.Time) EnvelopeStatusChangesParam {
-	return EnvelopeStatusChangesParam{Name: "to_date", Value: DsQueryTimeFormat(t)}
+// EnvelopeCreateChangeRoutingOrder allows the routing order of template
+// recipients to be changed.
+var EnvelopeCreateChangeRoutingOrder = EnvelopeCreateParam{
+	Name:  "change_routing_order",
+	Value: "true",
 }
 
-func StatusChangeStatusCode(status string) EnvelopeStatusChangesParam {
-	return EnvelopeStatusChangesParam{Name: "status", Value: status}
+// documentFiles orders files to match docs by UploadFile.Id.  Documents with
+// a RemoteUrl or DocumentBase64 are sent in the json payload and must not have
+// a file.  Files not matching a document (e.g. composite template documents)
+// are sent last.  When the ids of files do not match docs, e.g. files with
+// empty ids relying on their order, files are returned unchanged.
+func documentFiles(docs []Document, files []*UploadFile) ([]*UploadFile, error) {
+	byId := make(map[string]*UploadFile)
+	for _, f := range files {
+		if _, ok := byId[f.Id]; ok {
+			return files, nil
+		}
+		byId[f.Id] = f
+	}
+	var ordered []*UploadFile
+	for _, d := range docs {
+		f, ok := byId[d.DocumentId]
+		inline := d.RemoteUrl != "" || len(d.DocumentBase64) > 0
+		switch {
+		case ok && inline:
+			return nil, fmt.Errorf("docusign: document %s has both an uploaded file and remoteUrl or documentBase64", d.DocumentId)
+		case !ok && !inline && len(files) == 0:
+			return nil, fmt.Errorf("docusign: document %s has no uploaded file, remoteUrl or documentBase64", d.DocumentId)
+		case !ok && !inline:
+			return files, nil
+		case ok:
+			ordered = append(ordered, f)
+			delete(byId, d.DocumentId)
+		}
+	}
+	for _, f := range files {
+		if _, ok := byId[f.Id]; ok {
+			ordered = append(ordered, f)
+		}
+	}
+	return ordered, nil
 }
 
-func StatusChangeFromToStatus(status string) EnvelopeStatusChangesParam {
-	return EnvelopeStatusChangesParam{Name: "from_to_status", Value: status}
-}
+// SendTemplate creates and sends an envelope from a server template, assigning
+// the recipients to template roles.  Use SendTemplateDraft to save the
+// envelope as a draft instead.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Send%20an%20Envelope%20from%20a%20Template.htm
+func (s *Service) SendTemplate(ctx context.Context, templateId string, roles []TemplateRole, opts ...SendTemplateOption) (*EnvelopeResponse, error) {
+	env := &Envelope{
+		TemplateId:    templateId,
+		TemplateRoles: roles,
+		Status:        StatusSent,
+	}
+	for _, opt := range opts {
+		opt(env)
+	}
+	return s.EnvelopeCreate(ctx, env)
+}
+
+// SendTemplateOption modifies the envelope created by SendTemplate.
+type SendTemplateOption func(*Envelope)
+
+// SendTemplateDraft saves the envelope as a draft rather than sending.
+func SendTemplateDraft() SendTemplateOption {
+	return func(env *Envelope) {
+		env.Status = StatusCreated
+	}
+}
+
+// SendTemplateEmail overrides the template's email subject and blurb.
+// Empty values keep the template's settings.
+func SendTemplateEmail(subject, blurb string) SendTemplateOption {
+	return func(env *Envelope) {
+		env.EmailSubject = subject
+		env.EmailBlurb = blurb
+	}
+}
+
+// SendTemplateCustomField adds a text custom field to the envelope.
+func SendTemplateCustomField(name, value string) SendTemplateOption {
+	return func(env *Envelope) {
+		if env.CustomFields == nil {
+			env.CustomFields = &CustomFieldList{}
+		}
+		env.CustomFields.Set(name, value)
+	}
+}
+
+// EnvelopeUpdate modifies the top level fields (e.g. EmailSubject, EmailBlurb,
+// Notification) of a draft envelope.
+// Optional additions: resend_envelope={true}, advanced_update={true}
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Modify%20Draft%20Envelope%20Email%20Subject%20and%20Message.htm
+func (s *Service) EnvelopeUpdate(ctx context.Context, envId string, env *Envelope, args ...EnvelopeUpdateParam) (*EnvelopeResponse, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *EnvelopeResponse
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s", envId), RawQuery: q.Encode()},
+		Payload: env,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+type EnvelopeUpdateParam NmVal
+
+var EnvelopeUpdateResend = EnvelopeUpdateParam{
+	Name:  "resend_envelope",
+	Value: "true",
+}
+var EnvelopeUpdateAdvanced = EnvelopeUpdateParam{
+	Name:  "advanced_update",
+	Value: "true",
+}
+
+// EnvelopeLockCreate locks an envelope for editing.  The returned LockToken
+// is needed to update or delete the lock.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Lock%20Envelope.htm
+func (s *Service) EnvelopeLockCreate(ctx context.Context, envId string, req *LockRequest) (*LockInfo, error) {
+	var ret *LockInfo
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/lock", envId)},
+		Payload: req,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeLockGet returns the lock information of an envelope.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Lock%20Information.htm
+func (s *Service) EnvelopeLockGet(ctx context.Context, envId string) (*LockInfo, error) {
+	var ret *LockInfo
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/lock", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeLockUpdate updates (e.g. extends the duration of) a lock owned
+// by the caller.  lockToken is the LockToken returned by EnvelopeLockCreate.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Envelope%20Lock.htm
+func (s *Service) EnvelopeLockUpdate(ctx context.Context, envId string, lockToken string, req *LockRequest) (*LockInfo, error) {
+	var ret *LockInfo
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/lock", envId)},
+		Header:  lockHeader(lockToken, req),
+		Payload: req,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeLockDelete removes a lock owned by the caller.  lockToken is the
+// LockToken returned by EnvelopeLockCreate.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Delete%20Envelope%20Lock.htm
+func (s *Service) EnvelopeLockDelete(ctx context.Context, envId string, lockToken string) (*LockInfo, error) {
+	var ret *LockInfo
+	return ret, (&Call{
+		Method: "DELETE",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/lock", envId)},
+		Header: lockHeader(lockToken, nil),
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// lockHeader returns the X-DocuSign-Edit header identifying the lock
+// owner for update and delete calls.
+func lockHeader(lockToken string, req *LockRequest) http.Header {
+	var edit = struct {
+		LockToken             string `json:"LockToken"`
+		LockDurationInSeconds string `json:"LockDurationInSeconds,omitempty"`
+	}{LockToken: lockToken}
+	if req != nil {
+		edit.LockDurationInSeconds = req.LockDurationInSeconds
+	}
+	b, _ := json.Marshal(edit)
+	return http.Header{"X-Docusign-Edit": {string(b)}}
+}
+
+// unlockTimeout limits the release of an envelope lock by EnvelopeCorrect.
+const unlockTimeout = 30 * time.Second
+
+// EnvelopeCorrect applies recipient and tab changes to a sent envelope without
+// user interaction.  The envelope is locked while the changes are made and
+// the lock is always released, even when ctx is cancelled during the
+// changes.  Only envelopes with a status of sent or delivered may be corrected.
+func (s *Service) EnvelopeCorrect(ctx context.Context, envId string, changes *CorrectionRequest) error {
+	if changes == nil {
+		return fmt.Errorf("docusign: no corrections for envelope %s", envId)
+	}
+	env, err := s.EnvelopeStatus(ctx, envId)
+	if err != nil {
+		return err
+	}
+	if env.Status != StatusSent && env.Status != StatusDelivered {
+		return fmt.Errorf("docusign: envelope %s has status %s and cannot be corrected", envId, env.Status)
+	}
+	lock, err := s.EnvelopeLockCreate(ctx, envId, &LockRequest{
+		LockType:              "edit",
+		LockDurationInSeconds: changes.LockDurationInSeconds,
+	})
+	if err != nil {
+		return fmt.Errorf("docusign: unable to lock envelope %s for correction: %v", envId, err)
+	}
+	err = s.applyCorrection(ctx, envId, lock.LockToken, changes)
+	// release the lock even if ctx is done
+	unlockCtx, cancel := context.WithTimeout(detachedContext{ctx}, unlockTimeout)
+	defer cancel()
+	if _, unlockErr := s.EnvelopeLockDelete(unlockCtx, envId, lock.LockToken); err == nil && unlockErr != nil {
+		err = fmt.Errorf("docusign: corrections applied but unable to unlock envelope %s: %v", envId, unlockErr)
+	}
+	return err
+}
+
+// applyCorrection makes the changes of a CorrectionRequest using
+// the lock identified by lockToken.
+func (s *Service) applyCorrection(ctx context.Context, envId string, lockToken string, changes *CorrectionRequest) error {
+	hdr := lockHeader(lockToken, nil)
+	if changes.Recipients != nil {
+		var res *RecipientUpdateResult
+		if err := (&Call{
+			Method:  "PUT",
+			URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients", envId)},
+			Header:  hdr,
+			Payload: changes.Recipients,
+			Result:  &res,
+		}).Do(ctx, s); err != nil {
+			return err
+		}
+		for i := 0; res != nil && i < len(res.RecipientUpdateResults); i++ {
+			if r := res.RecipientUpdateResults[i]; r.ErrorDetails != nil {
+				return fmt.Errorf("docusign: recipient %s correction failed: %v", r.RecipientId, r.ErrorDetails)
+			}
+		}
+	}
+	ids := make([]string, 0, len(changes.Tabs))
+	for id := range changes.Tabs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		var res *Tabs
+		if err := (&Call{
+			Method:  "PUT",
+			URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/tabs", envId, id)},
+			Header:  hdr,
+			Payload: changes.Tabs[id],
+			Result:  &res,
+		}).Do(ctx, s); err != nil {
+			return err
+		}
+		if res == nil {
+			continue
+		}
+		if err := res.FirstError(); err != nil {
+			return fmt.Errorf("docusign: recipient %s tab correction failed: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// EnvelopeStatusChanges returns envelope status changes for all envelopes. The information returned can be
+// modified by adding query strings to limit the request to check between certain dates and times, or for certain envelopes,
+// or for certain status codes. It is recommended that you use one or more of the query strings in order to limit the size of the response.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST API References/Get Envelope Status Changes.htm
+//
+// Optional query strings: from_date={dateTime}, to_date={dateTime}, status={status code}, from_to_status={changed or any or list of statuses},
+// envelopeId={envelopeId}, custom_field={envelope custom field name}={envelope custom field value}, transaction_ids={transactionIds (comma separated)}
+//
+// Use DsQueryTimeFormat to formate dateTime query arguments
+func (s *Service) EnvelopeStatusChanges(ctx context.Context, args ...EnvelopeStatusChangesParam) (*EnvelopeList, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *EnvelopeList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "envelopes", RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type EnvelopeStatusChangesParam NmVal
+
+func StatusChangeFromDate(t time.Time) EnvelopeStatusChangesParam {
+	return EnvelopeStatusChangesParam{Name: "from_date", Value: DsQueryTimeFormat(t)}
+}
+
+func StatusChangeToDate(t time.Time) EnvelopeStatusChangesParam {
+	return EnvelopeStatusChangesParam{Name: "to_date", Value: DsQueryTimeFormat(t)}
+}
+
+func StatusChangeStatusCode(status EnvelopeStatus) EnvelopeStatusChangesParam {
+	return EnvelopeStatusChangesParam{Name: "status", Value: status}
+}
+
+func StatusChangeFromToStatus(status string) EnvelopeStatusChangesParam {
+	return EnvelopeStatusChangesParam{Name: "from_to_status", Value: status}
+}
 
 func StatusChangeEnvelope(envId string) EnvelopeStatusChangesParam {
 	return EnvelopeStatusChangesParam{Name: "envelopeId", Value: envId}
@@ -456,6 +1262,44 @@ func StatusChangeTransactionId(transactionIds ...string) EnvelopeStatusChangesPa
 	return EnvelopeStatusChangesParam{Name: "transaction_ids", Value: strings.Join(transactionIds, ",")}
 }
 
+// EnvelopeGet returns the envelope definition including the information
+// specified by includes.
+//
+//	env, err := sv.EnvelopeGet(ctx, envId, EnvelopeIncludeRecipients, EnvelopeIncludeTabs)
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Status%20for%20One%20Envelope.htm
+func (s *Service) EnvelopeGet(ctx context.Context, envId string, includes ...EnvelopeInclude) (*Envelope, error) {
+	q := make(url.Values)
+	if len(includes) > 0 {
+		vals := make([]string, len(includes))
+		for i, inc := range includes {
+			vals[i] = string(inc)
+		}
+		q.Set("include", strings.Join(vals, ","))
+	}
+	var ret *Envelope
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s", envId), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeInclude specifies additional information returned by EnvelopeGet.
+type EnvelopeInclude string
+
+const (
+	EnvelopeIncludeRecipients   EnvelopeInclude = "recipients"
+	EnvelopeIncludeDocuments    EnvelopeInclude = "documents"
+	EnvelopeIncludeCustomFields EnvelopeInclude = "custom_fields"
+	EnvelopeIncludeTabs         EnvelopeInclude = "tabs"
+	EnvelopeIncludeAttachments  EnvelopeInclude = "attachments"
+	EnvelopeIncludeExtensions   EnvelopeInclude = "extensions"
+	EnvelopeIncludePowerForm    EnvelopeInclude = "powerform"
+	EnvelopeIncludeFolders      EnvelopeInclude = "folders"
+)
+
 // EnvelopeStatus returns returns the overall status for a single envelope.
 //
 // RestApi Documentation
@@ -470,22 +1314,232 @@ func (s *Service) EnvelopeStatus(ctx context.Context, envId string) (res *Envelo
 	return ret, c.Do(ctx, s)
 }
 
-// EnvelopeStatusMulti returns the status for the requested envelopes.
+// WatchEnvelope polls EnvelopeStatus every interval and sends the result on the
+// returned channel each time the envelope's status changes, beginning with the
+// first status read.  Both channels are closed once the envelope is completed,
+// declined or voided.  A poll failing with a retryable ResponseError or a
+// transport error, such as a dns failure or timeout, is retried at the next
+// interval.  A non-retryable ResponseError, a cancelled ctx or a non-positive
+// interval ends the watch, and the error is sent on the error channel before
+// it closes.
+func (s *Service) WatchEnvelope(ctx context.Context, envId string, interval time.Duration) (<-chan EnvelopeUris, <-chan error) {
+	updates := make(chan EnvelopeUris)
+	errs := make(chan error, 1)
+	if interval <= 0 {
+		errs <- fmt.Errorf("docusign: WatchEnvelope interval must be positive; got %v", interval)
+		close(errs)
+		close(updates)
+		return updates, errs
+	}
+	go func() {
+		defer close(errs)
+		defer close(updates)
+		var last EnvelopeStatus
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			res, err := s.EnvelopeStatus(ctx, envId)
+			if err != nil {
+				if ctx.Err() != nil {
+					errs <- ctx.Err()
+					return
+				}
+				// transport errors, e.g. a dns failure or reset
+				// connection, are retried with retryable responses
+				if re, ok := err.(*ResponseError); ok && !re.IsRetryable() {
+					errs <- err
+					return
+				}
+			} else if res.Status != last {
+				last = res.Status
+				select {
+				case updates <- *res:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			switch last {
+			case StatusCompleted, StatusDeclined, StatusVoided:
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return updates, errs
+}
+
+// EnvelopeStatusMulti returns the status for the requested envelopes.  Ids are
+// sent in batches of maxEnvelopeStatusIds, and results are returned in the order
+// of envIds, skipping ids with no result.  The first error encountered stops
+// processing, and the results read before it are returned with the error.
 //
 // RestApi Documentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Status%20for%20more%20than%20one%20envelope.htm
 func (s *Service) EnvelopeStatusMulti(ctx context.Context, envIds ...string) ([]EnvelopeUris, error) {
-	var retVal struct {
-		Envelopes     []EnvelopeUris `json:"envelopes"`
-		ResultSetSize string         `json:"resultSetSize"`
+	byId := make(map[string]EnvelopeUris)
+	ordered := func() []EnvelopeUris {
+		var results []EnvelopeUris
+		for _, id := range envIds {
+			if env, ok := byId[id]; ok {
+				results = append(results, env)
+			}
+		}
+		return results
 	}
-	envList := map[string][]string{"envelopeIds": envIds}
-	return retVal.Envelopes, (&Call{
-		Method:  "PUT",
-		URL:     &url.URL{Path: "envelopes/status", RawQuery: "envelope_ids=request_body"},
-		Payload: envList,
-		Result:  &retVal,
-	}).Do(ctx, s)
+	for ids := envIds; len(ids) > 0; {
+		batch := ids
+		if len(batch) > maxEnvelopeStatusIds {
+			batch = batch[:maxEnvelopeStatusIds]
+		}
+		ids = ids[len(batch):]
+
+		var retVal struct {
+			Envelopes     []EnvelopeUris `json:"envelopes"`
+			ResultSetSize string         `json:"resultSetSize"`
+		}
+		envList := map[string][]string{"envelopeIds": batch}
+		if err := (&Call{
+			Method:  "PUT",
+			URL:     &url.URL{Path: "envelopes/status", RawQuery: "envelope_ids=request_body"},
+			Payload: envList,
+			Result:  &retVal,
+		}).Do(ctx, s); err != nil {
+			return ordered(), err
+		}
+		for _, env := range retVal.Envelopes {
+			byId[env.EnvelopeId] = env
+		}
+	}
+	return ordered(), nil
+}
+
+// maxEnvelopeStatusIds is the largest number of envelope ids DocuSign
+// accepts in a single EnvelopeStatusMulti request.
+const maxEnvelopeStatusIds = 1000
+
+// EnvelopeStatusBatch returns the status of each envelope keyed by envelope id,
+// running up to concurrency requests at once.  Ids are requested in batches
+// using the multiple envelope status call.  Ids missing from a batch result,
+// and the ids of a batch rejected with a non-retryable ResponseError such as
+// a bad id, are retried individually using EnvelopeStatus.  Other batch
+// errors, including rate limits and auth errors, are recorded for each id of
+// the batch without further calls.
+//
+// Ids whose status could not be read are reported in an EnvelopeStatusErrors;
+// the returned map contains the remaining results.  If ctx is cancelled,
+// ctx.Err() is returned with the results read so far.
+func (s *Service) EnvelopeStatusBatch(ctx context.Context, envIds []string, concurrency int) (map[string]*EnvelopeUris, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var batches [][]string
+	for ids := envIds; len(ids) > 0; {
+		n := len(ids)
+		if n > maxEnvelopeStatusIds {
+			n = maxEnvelopeStatusIds
+		}
+		batches, ids = append(batches, ids[:n]), ids[n:]
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]*EnvelopeUris)
+	errs := make(EnvelopeStatusErrors)
+	var singles []string
+	err := runWorkers(ctx, concurrency, len(batches), func(i int) {
+		list, err := s.EnvelopeStatusMulti(ctx, batches[i]...)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if re, ok := err.(*ResponseError); ok && !re.IsAuthError() && !re.IsRetryable() {
+				// e.g. a bad id rejecting the whole batch
+				singles = append(singles, batches[i]...)
+				return
+			}
+			// individual calls would fail the same way, and rate limits
+			// would only be made worse by more calls
+			for _, id := range batches[i] {
+				errs[id] = err
+			}
+			return
+		}
+		for j := range list {
+			results[list[j].EnvelopeId] = &list[j]
+		}
+		for _, id := range batches[i] {
+			if _, ok := results[id]; !ok {
+				singles = append(singles, id)
+			}
+		}
+	})
+	if err == nil {
+		err = runWorkers(ctx, concurrency, len(singles), func(i int) {
+			res, err := s.EnvelopeStatus(ctx, singles[i])
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[singles[i]] = err
+				return
+			}
+			results[singles[i]] = res
+		})
+	}
+	if err == nil && len(errs) > 0 {
+		err = errs
+	}
+	return results, err
+}
+
+// EnvelopeStatusErrors maps envelope ids to the error returned
+// reading their status by EnvelopeStatusBatch.
+type EnvelopeStatusErrors map[string]error
+
+func (e EnvelopeStatusErrors) Error() string {
+	if len(e) == 0 {
+		return "docusign: status failed for 0 envelope(s)"
+	}
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("docusign: status failed for %d envelope(s); %s: %v", len(ids), ids[0], e[ids[0]])
+}
+
+// runWorkers calls fn for each i in [0,n) using up to concurrency
+// goroutines.  No new calls are started once ctx is done, in which
+// case ctx.Err() is returned after running calls finish.
+func runWorkers(ctx context.Context, concurrency int, n int, fn func(i int)) error {
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				fn(i)
+			}
+		}()
+	}
+	var err error
+	for i := 0; i < n && err == nil; i++ {
+		select {
+		case idx <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(idx)
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
 }
 
 func (s *Service) EnvelopeSetDocuments(ctx context.Context, envId string, dl *DocumentList, files ...*UploadFile) (*DocumentAssetList, error) {
@@ -570,6 +1624,225 @@ func (s *Service) Recipients(ctx context.Context, envId string, args ...Recipien
 	}).Do(ctx, s)
 }
 
+// ReorderRecipients sets the routing order of the listed recipients to their
+// position (1..n) in orderByRecipientId.  Recipients not listed are unchanged.
+// An error is returned without modifying the envelope if an id is not a recipient
+// of the envelope or is listed twice.
+func (s *Service) ReorderRecipients(ctx context.Context, envId string, orderByRecipientId []string) (*RecipientUpdateResult, error) {
+	order := make(map[string]string)
+	for i, id := range orderByRecipientId {
+		if _, ok := order[id]; ok {
+			return nil, fmt.Errorf("docusign: recipient id %s listed more than once", id)
+		}
+		order[id] = strconv.Itoa(i + 1)
+	}
+	rl, err := s.Recipients(ctx, envId)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	reorder := func(id string) (Recipient, bool) {
+		routingOrder, ok := order[id]
+		found[id] = ok
+		return Recipient{RecipientId: id, RoutingOrder: routingOrder}, ok
+	}
+	upd := &RecipientList{}
+	for _, x := range rl.Agents {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.Agents = append(upd.Agents, Agent{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, x := range rl.CarbonCopies {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.CarbonCopies = append(upd.CarbonCopies, CarbonCopy{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, x := range rl.CertifiedDeliveries {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.CertifiedDeliveries = append(upd.CertifiedDeliveries, CertifiedDelivery{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, x := range rl.Editors {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.Editors = append(upd.Editors, Editor{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, x := range rl.InPersonSigners {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.InPersonSigners = append(upd.InPersonSigners, InPersonSigner{Recipient: r})
+		}
+	}
+	for _, x := range rl.Intermediaries {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.Intermediaries = append(upd.Intermediaries, Intermediary{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, x := range rl.Seals {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.Seals = append(upd.Seals, SealSign{RecipientId: r.RecipientId, RoutingOrder: r.RoutingOrder})
+		}
+	}
+	for _, x := range rl.Signers {
+		if r, ok := reorder(x.RecipientId); ok {
+			upd.Signers = append(upd.Signers, Signer{EmailRecipient: EmailRecipient{Recipient: r}})
+		}
+	}
+	for _, id := range orderByRecipientId {
+		if !found[id] {
+			return nil, fmt.Errorf("docusign: recipient id %s not found in envelope %s", id, envId)
+		}
+	}
+	return s.RecipientsModify(ctx, envId, upd)
+}
+
+// EnvelopePrefill updates the tabs of several recipients in a single call.
+// byRecipient maps a recipientId to the tabs to modify; as with
+// RecipientTabsModify each tab must include its tabId.  Only signers and in
+// person signers have tabs.
+//
+// The returned list contains the updated recipients.  A failed recipient has
+// ErrorDetails set and failed tabs have their ErrorDetails set (see
+// Tabs.FirstError).
+func (s *Service) EnvelopePrefill(ctx context.Context, envId string, byRecipient map[string]*Tabs) (*RecipientList, error) {
+	rl, err := s.Recipients(ctx, envId)
+	if err != nil {
+		return nil, err
+	}
+	upd := &RecipientList{}
+	found := make(map[string]bool)
+	for _, x := range rl.InPersonSigners {
+		if tabs, ok := byRecipient[x.RecipientId]; ok {
+			found[x.RecipientId] = true
+			upd.InPersonSigners = append(upd.InPersonSigners, InPersonSigner{
+				Recipient:  Recipient{RecipientId: x.RecipientId},
+				BaseSigner: BaseSigner{Tabs: tabs},
+			})
+		}
+	}
+	for _, x := range rl.Signers {
+		if tabs, ok := byRecipient[x.RecipientId]; ok {
+			found[x.RecipientId] = true
+			upd.Signers = append(upd.Signers, Signer{
+				EmailRecipient: EmailRecipient{Recipient: Recipient{RecipientId: x.RecipientId}},
+				BaseSigner:     BaseSigner{Tabs: tabs},
+			})
+		}
+	}
+	ids := make([]string, 0, len(byRecipient))
+	for id := range byRecipient {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("docusign: recipient id %s is not a signer of envelope %s", id, envId)
+		}
+	}
+
+	var ret struct {
+		RecipientUpdateResults []struct {
+			RecipientId  string         `json:"recipientId"`
+			Tabs         *Tabs          `json:"tabs"`
+			ErrorDetails *ResponseError `json:"errorDetails"`
+		} `json:"recipientUpdateResults"`
+	}
+	if err = (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients", envId)},
+		Payload: upd,
+		Result:  &ret,
+	}).Do(ctx, s); err != nil {
+		return nil, err
+	}
+	// copy results onto the submitted recipients
+	apply := func(r *Recipient, bs *BaseSigner) {
+		for _, res := range ret.RecipientUpdateResults {
+			if res.RecipientId == r.RecipientId {
+				r.ErrorDetails = res.ErrorDetails
+				if res.Tabs != nil {
+					bs.Tabs = res.Tabs
+				}
+			}
+		}
+	}
+	for i := range upd.InPersonSigners {
+		apply(&upd.InPersonSigners[i].Recipient, &upd.InPersonSigners[i].BaseSigner)
+	}
+	for i := range upd.Signers {
+		apply(&upd.Signers[i].Recipient, &upd.Signers[i].BaseSigner)
+	}
+	return upd, nil
+}
+
+// EnvelopePayments returns the payments collected by the payment tabs of an
+// envelope's signers.  DocuSign reports payments in the PaymentDetails of
+// formula tabs, so the recipients are retrieved with their tabs.
+func (s *Service) EnvelopePayments(ctx context.Context, envId string) (*PaymentList, error) {
+	rl, err := s.Recipients(ctx, envId, RecipientsIncludeTabs)
+	if err != nil {
+		return nil, err
+	}
+	ret := &PaymentList{}
+	rl.eachTabs(func(recipId string, tabs *Tabs) {
+		if tabs == nil {
+			return
+		}
+		for _, f := range tabs.FormulaTabs {
+			pd := f.PaymentDetails
+			if !bool(f.IsPaymentAmount) || pd == nil {
+				continue
+			}
+			p := Payment{
+				RecipientId:      recipId,
+				TabLabel:         f.TabLabel,
+				PaymentId:        pd.ChargeId,
+				Status:           pd.Status,
+				Currency:         pd.CurrencyCode,
+				GatewayAccountId: pd.GatewayAccountId,
+				Details:          pd,
+			}
+			if pd.Total != nil {
+				p.Amount = pd.Total.DisplayAmount
+				if pd.Total.Currency != "" {
+					p.Currency = pd.Total.Currency
+				}
+			}
+			ret.Payments = append(ret.Payments, p)
+		}
+	})
+	return ret, nil
+}
+
+// EnvelopeAllTabs returns the tabs of every recipient of an envelope keyed
+// by recipientId using a single Recipients call.
+func (s *Service) EnvelopeAllTabs(ctx context.Context, envId string) (map[string]*Tabs, error) {
+	rl, err := s.Recipients(ctx, envId, RecipientsIncludeTabs)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*Tabs)
+	rl.eachTabs(func(recipId string, tabs *Tabs) {
+		if tabs != nil {
+			ret[recipId] = tabs
+		}
+	})
+	return ret, nil
+}
+
+// EnvelopeFormData returns the envelope level and recipient form field values
+// of an envelope.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Form%20Data.htm
+func (s *Service) EnvelopeFormData(ctx context.Context, envId string) (*EnvelopeFormData, error) {
+	var ret *EnvelopeFormData
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/form_data", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
 type RecipientsParam NmVal
 
 var RecipientsIncludeTabs = RecipientsParam{
@@ -589,6 +1862,27 @@ var RecipientsResend = RecipientsParam{
 	Value: "true",
 }
 
+// RecipientsOpt names an optional include query string of Recipients.
+type RecipientsOpt string
+
+const (
+	RecipientsOptTabs               RecipientsOpt = "include_tabs"
+	RecipientsOptExtended           RecipientsOpt = "include_extended"
+	RecipientsOptAnchorTabLocations RecipientsOpt = "include_anchor_tab_locations"
+	RecipientsOptMetadata           RecipientsOpt = "include_metadata"
+)
+
+// RecipientsIncludeOpts returns a true query string for each opt.
+//
+//	rl, err := sv.Recipients(ctx, envId, RecipientsIncludeOpts(RecipientsOptTabs, RecipientsOptExtended)...)
+func RecipientsIncludeOpts(opts ...RecipientsOpt) []RecipientsParam {
+	params := make([]RecipientsParam, len(opts))
+	for i, o := range opts {
+		params[i] = RecipientsParam{Name: string(o), Value: "true"}
+	}
+	return params
+}
+
 // RecipientsAdd
 // If an error occurred during the operation, recipient struct will contain an ErrorDetail
 // Optional addition: resend_envelope {true or false}
@@ -642,6 +1936,44 @@ func (s *Service) RecipientsRemove(ctx context.Context, envId string, rl *Recipi
 	}).Do(ctx, s)
 }
 
+// RecipientRemoveById removes the recipients with the listed ids from an envelope.
+// DocuSign deletes by recipientId regardless of recipient type, so every id
+// is sent as a signer.
+func (s *Service) RecipientRemoveById(ctx context.Context, envId string, recipientIds ...string) (*RecipientList, error) {
+	rl := &RecipientList{Signers: make([]Signer, len(recipientIds))}
+	for i, id := range recipientIds {
+		rl.Signers[i].RecipientId = id
+	}
+	return s.RecipientsRemove(ctx, envId, rl)
+}
+
+// RecipientDocumentVisibility returns the documents visible to a recipient.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Document%20Visibility%20for%20Recipient.htm
+func (s *Service) RecipientDocumentVisibility(ctx context.Context, envId string, recipId string) (*DocumentVisibilityList, error) {
+	var ret *DocumentVisibilityList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/document_visibility", envId, recipId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// RecipientDocumentVisibilityUpdate sets the documents visible to a recipient.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Document%20Visibility%20for%20Recipient.htm
+func (s *Service) RecipientDocumentVisibilityUpdate(ctx context.Context, envId string, recipId string, dvl *DocumentVisibilityList) (*DocumentVisibilityList, error) {
+	var ret *DocumentVisibilityList
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/document_visibility", envId, recipId)},
+		Payload: dvl,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
 // RecipientTabs
 // RestApiDocumentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Tab%20Information%20for%20a%20Recipient.htm
@@ -654,6 +1986,48 @@ func (s *Service) RecipientTabs(ctx context.Context, envId string, recipId strin
 	}).Do(ctx, s)
 }
 
+// DocumentTabs returns every tab placed on the document, regardless of
+// recipient.  Each tab includes its page number and position.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Tabs%20for%20a%20Document.htm
+func (s *Service) DocumentTabs(ctx context.Context, envId string, docId string) (*Tabs, error) {
+	var ret *Tabs
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s/tabs", envId, docId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// DocumentHtmlDefinitions returns the html definitions stored for a
+// responsive document.
+//
+// RestApiDocumentation
+// https://developers.docusign.com/esign-rest-api/v2/reference/Envelopes/Documents/getHtmlDefinitions
+func (s *Service) DocumentHtmlDefinitions(ctx context.Context, envId string, docId string) (*HtmlDefinitionList, error) {
+	var ret *HtmlDefinitionList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s/html_definitions", envId, docId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeHtmlDefinitions returns the html definitions stored for all
+// responsive documents of an envelope.
+//
+// RestApiDocumentation
+// https://developers.docusign.com/esign-rest-api/v2/reference/Envelopes/Envelopes/getHtmlDefinitions
+func (s *Service) EnvelopeHtmlDefinitions(ctx context.Context, envId string) (*HtmlDefinitionList, error) {
+	var ret *HtmlDefinitionList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/html_definitions", envId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
 // RecipientTabsAdd adds tabs to a recipient The response returns the success or failure of each document being added
 // to the envelope and the envelope ID. Failed operations will add the ErrorDetails structure containing
 // an error code and message. If ErrorDetails is nil, then the operation was successful for that item.
@@ -669,6 +2043,24 @@ func (s *Service) RecipientTabsAdd(ctx context.Context, envId string, recipId st
 	}).Do(ctx, s)
 }
 
+// RecipientCloneTabs copies the tabs of recipient fromRecipId to toRecipId,
+// e.g. to give a newly added signer the layout of an existing signer.  Tab
+// values are copied; EnvelopeIdTabs are skipped.  The added tabs are returned.
+func (s *Service) RecipientCloneTabs(ctx context.Context, envId string, fromRecipId string, toRecipId string) (*Tabs, error) {
+	src, err := s.RecipientTabs(ctx, envId, fromRecipId)
+	if err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return nil, fmt.Errorf("docusign: recipient %s has no tabs", fromRecipId)
+	}
+	tb, err := src.cloneFor(toRecipId)
+	if err != nil {
+		return nil, err
+	}
+	return s.RecipientTabsAdd(ctx, envId, toRecipId, tb)
+}
+
 // RecipientTabsModify
 // The parameters used to modify tabs are the same as those used in an envelope, but you can only modify existing tabs
 // and the tabId must be included.
@@ -699,6 +2091,92 @@ func (s *Service) RecipientTabsRemove(ctx context.Context, envId string, recipId
 	}).Do(ctx, s) //urlStr := fmt.Sprintf("envelopes/%s/recipients/%s/tabs", envId, recipId)
 }
 
+// TemplateRecipientTabs returns the tabs of a template recipient.  Use Tabs.Values
+// to list the tab labels available for prefilling.
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Tab%20Information%20for%20a%20Recipient%20in%20a%20Template.htm
+func (s *Service) TemplateRecipientTabs(ctx context.Context, templateId string, recipId string) (*Tabs, error) {
+	var ret *Tabs
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("templates/%s/recipients/%s/tabs", templateId, recipId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// TemplateRecipientTabsAdd adds tabs to a template recipient.  Failed operations will
+// add the ErrorDetails structure to the tab.
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Add%20Tabs%20for%20a%20Recipient%20in%20a%20Template.htm
+func (s *Service) TemplateRecipientTabsAdd(ctx context.Context, templateId string, recipId string, tb *Tabs) (*Tabs, error) {
+	var ret *Tabs
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: fmt.Sprintf("templates/%s/recipients/%s/tabs", templateId, recipId)},
+		Payload: tb,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// TemplateRecipientTabsModify modifies existing tabs of a template recipient.  The
+// tabId must be included.
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Modify%20Tabs%20for%20a%20Recipient%20in%20a%20Template.htm
+func (s *Service) TemplateRecipientTabsModify(ctx context.Context, templateId string, recipId string, tb *Tabs) (*Tabs, error) {
+	var ret *Tabs
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("templates/%s/recipients/%s/tabs", templateId, recipId)},
+		Payload: tb,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// TemplateRecipientTabsRemove deletes tabs from a template recipient.
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Delete%20Tabs%20for%20a%20Recipient%20in%20a%20Template.htm
+func (s *Service) TemplateRecipientTabsRemove(ctx context.Context, templateId string, recipId string, tb *Tabs) (*Tabs, error) {
+	var ret *Tabs
+	return ret, (&Call{
+		Method:  "DELETE",
+		URL:     &url.URL{Path: fmt.Sprintf("templates/%s/recipients/%s/tabs", templateId, recipId)},
+		Payload: tb,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// BulkRecipients returns the bulk recipients of a bulk send signer.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Bulk%20Recipients.htm
+func (s *Service) BulkRecipients(ctx context.Context, envId string, recipId string) (*BulkRecipientList, error) {
+	var ret *BulkRecipientList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/bulk_recipients", envId, recipId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BulkRecipientsAdd replaces the bulk recipients of a bulk send signer.  The list
+// is uploaded as a csv file with a column for each tab label.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Add%20Bulk%20Recipients.htm
+func (s *Service) BulkRecipientsAdd(ctx context.Context, envId string, recipId string, list *BulkRecipientList) (*BulkRecipientList, error) {
+	b, err := list.csv()
+	if err != nil {
+		return nil, err
+	}
+	var ret *BulkRecipientList
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/recipients/%s/bulk_recipients", envId, recipId)},
+		Payload: &UploadFile{ContentType: "text/csv", Data: bytes.NewReader(b)},
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
 // TemplateSearch retrieves the list of templates for the specified account
 // Optional query strings: folder={string}, folder_ids={GUID, GUID}, include={string}, count={integer},
 // start_position={integer}, from_date={date/time}, to_date={date/time}, used_from_date={date/time},
@@ -729,6 +2207,9 @@ func TemplateSearchFolderIds(folder ...string) TemplateSearchParam {
 	return TemplateSearchParam{Name: "folder", Value: strings.Join(folder, ",")}
 }
 
+// TemplateSearchInclude adds the include query string for each true parameter.
+//
+// Deprecated: use TemplateSearchIncludeOpts.
 func TemplateSearchInclude(recipients, folders, documents, customFields, notifications bool) TemplateSearchParam {
 	vals := make([]string, 0, 5)
 	if recipients {
@@ -749,6 +2230,26 @@ func TemplateSearchInclude(recipients, folders, documents, customFields, notific
 	return TemplateSearchParam{Name: "include", Value: strings.Join(vals, ",")}
 }
 
+// TemplateInclude is a value of the TemplateSearch include query string.
+type TemplateInclude string
+
+const (
+	TemplateIncludeRecipients    TemplateInclude = "recipients"
+	TemplateIncludeFolders       TemplateInclude = "folders"
+	TemplateIncludeDocuments     TemplateInclude = "documents"
+	TemplateIncludeCustomFields  TemplateInclude = "custom_fields"
+	TemplateIncludeNotifications TemplateInclude = "notifications"
+)
+
+// TemplateSearchIncludeOpts returns an include query string containing opts.
+func TemplateSearchIncludeOpts(opts ...TemplateInclude) TemplateSearchParam {
+	vals := make([]string, len(opts))
+	for i, o := range opts {
+		vals[i] = string(o)
+	}
+	return TemplateSearchParam{Name: "include", Value: strings.Join(vals, ",")}
+}
+
 func TemplateSearchCount(count int) TemplateSearchParam {
 	return TemplateSearchParam{Name: "count", Value: strconv.Itoa(count)}
 }
@@ -848,6 +2349,9 @@ func (s *Service) EnvelopeCorrection(ctx context.Context, envId string, retUrlTy
 // RestApiDocumentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Post%20Recipient%20View.htm
 func (s *Service) RecipientView(ctx context.Context, envId string, er *EnvRecipientView) (*EnvUrl, error) {
+	if err := er.AuthenticationMethod.Valid(); err != nil {
+		return nil, err
+	}
 	var ret *EnvUrl
 	return ret, (&Call{
 		Method:  "POST",
@@ -857,6 +2361,76 @@ func (s *Service) RecipientView(ctx context.Context, envId string, er *EnvRecipi
 	}).Do(ctx, s)
 }
 
+// EmbeddedSigningURL returns the url of an embedded signing session for
+// signer.  The signer must have been added to the envelope with a
+// ClientUserId.
+func (s *Service) EmbeddedSigningURL(ctx context.Context, envId string, signer Signer, returnURL string) (string, error) {
+	if signer.ClientUserId == "" {
+		return "", fmt.Errorf("docusign: embedded signing requires a signer with a ClientUserId")
+	}
+	res, err := s.RecipientView(ctx, envId, &EnvRecipientView{
+		ClientUserId:         signer.ClientUserId,
+		AuthenticationMethod: AuthMethodNone,
+		Email:                signer.Email,
+		UserName:             signer.Name,
+		UserId:               signer.UserId,
+		ReturnUrl:            ReturnUrlType(returnURL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Url, nil
+}
+
+// TemplateApplyAndView sends a new envelope created from templateId and roles,
+// then returns the recipient view for the role whose ClientUserId matches
+// view.ClientUserId.  Email and UserName are taken from the matched role
+// when not set in view, and an empty AuthenticationMethod defaults to
+// AuthMethodNone.  The view is checked before the envelope is sent.  If the
+// view cannot be created after sending, the error includes the envelope id
+// so that the envelope may be voided.
+func (s *Service) TemplateApplyAndView(ctx context.Context, templateId string, roles []TemplateRole, view *EnvRecipientView) (*EnvUrl, error) {
+	if view == nil || view.ClientUserId == "" {
+		return nil, fmt.Errorf("docusign: embedded signing requires a view with a ClientUserId")
+	}
+	rv := *view
+	if rv.AuthenticationMethod == "" {
+		rv.AuthenticationMethod = AuthMethodNone
+	}
+	if err := rv.AuthenticationMethod.Valid(); err != nil {
+		return nil, err
+	}
+	var role *TemplateRole
+	for i := range roles {
+		if roles[i].ClientUserId == view.ClientUserId {
+			role = &roles[i]
+			break
+		}
+	}
+	if role == nil {
+		return nil, fmt.Errorf("docusign: no template role with ClientUserId %q", view.ClientUserId)
+	}
+	res, err := s.EnvelopeCreate(ctx, &Envelope{
+		TemplateId:    templateId,
+		TemplateRoles: roles,
+		Status:        StatusSent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rv.Email == "" {
+		rv.Email = role.Email
+	}
+	if rv.UserName == "" {
+		rv.UserName = role.Name
+	}
+	u, err := s.RecipientView(ctx, res.EnvelopeId, &rv)
+	if err != nil {
+		return nil, fmt.Errorf("docusign: envelope %s sent but recipient view failed: %v", res.EnvelopeId, err)
+	}
+	return u, nil
+}
+
 // SenderView returns a URL to start the sender view of the DocuSign UI.
 //
 // RestApiDocumentation
@@ -887,6 +2461,25 @@ func (s *Service) EditView(ctx context.Context, envId string, retType ReturnUrlT
 
 }
 
+// ConsoleView returns a URL to start the DocuSign console at the envelope, or
+// at the console's landing page when envId is empty.  returnURL, if not empty,
+// is where the user is sent when leaving the console.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Post%20Console%20View.htm
+func (s *Service) ConsoleView(ctx context.Context, envId string, returnURL string) (*EnvUrl, error) {
+	var ret *EnvUrl
+	return ret, (&Call{
+		Method: "POST",
+		URL:    &url.URL{Path: "views/console"},
+		Payload: &struct {
+			EnvelopeId string `json:"envelopeId,omitempty"`
+			ReturnUrl  string `json:"returnUrl,omitempty"`
+		}{envId, returnURL},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
 // EnvelopeTemplates returns a list of templates used by an envelope
 //
 // RestApiDocumentation
@@ -901,6 +2494,32 @@ func (s *Service) EnvelopeTemplates(ctx context.Context, envId string) (*Templat
 
 }
 
+// EnvelopeApplyTemplate applies templates to documents of a draft envelope.  The
+// returned list reports the ErrorDetails of each failed item.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Apply%20Template%20to%20Envelope.htm
+func (s *Service) EnvelopeApplyTemplate(ctx context.Context, envId string, apply *TemplateApply) (*TemplateApply, error) {
+	var ret *TemplateApply
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s/templates", envId)},
+		Payload: apply,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeRemoveTemplate removes a template applied to a document of a draft envelope.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Delete%20Template%20from%20Document.htm
+func (s *Service) EnvelopeRemoveTemplate(ctx context.Context, envId string, docId string, templateId string) error {
+	return (&Call{
+		Method: "DELETE",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/%s/documents/%s/templates/%s", envId, docId, templateId)},
+	}).Do(ctx, s)
+}
+
 // EnvelopeMove move the specified envelope to the folder specified int toFolderId
 //
 // RestApiDocumentation
@@ -914,6 +2533,105 @@ func (s *Service) EnvelopeMove(ctx context.Context, toFolderId string, envIds ..
 	}).Do(ctx, s)
 }
 
+// EnvelopeTransferRules returns the account's envelope transfer rules.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Envelope%20Transfer%20Rules.htm
+func (s *Service) EnvelopeTransferRules(ctx context.Context) (*EnvelopeTransferRuleList, error) {
+	var ret *EnvelopeTransferRuleList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "envelopes/transfer_rules"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeTransferRuleCreate adds transfer rules to the account.  A rule
+// is created for each of the request's FromUsers and FromGroups.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20Envelope%20Transfer%20Rules.htm
+func (s *Service) EnvelopeTransferRuleCreate(ctx context.Context, rule *EnvelopeTransferRuleRequest) (*EnvelopeTransferRuleList, error) {
+	var ret *EnvelopeTransferRuleList
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "envelopes/transfer_rules"},
+		Payload: rule,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeTransferRuleUpdate changes an envelope transfer rule, e.g. to
+// disable it.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Envelope%20Transfer%20Rule.htm
+func (s *Service) EnvelopeTransferRuleUpdate(ctx context.Context, ruleId string, rule *EnvelopeTransferRule) (*EnvelopeTransferRule, error) {
+	var ret *EnvelopeTransferRule
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/transfer_rules/%s", ruleId)},
+		Payload: rule,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// EnvelopeTransferRuleDelete removes an envelope transfer rule.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Delete%20Envelope%20Transfer%20Rule.htm
+func (s *Service) EnvelopeTransferRuleDelete(ctx context.Context, ruleId string) error {
+	return (&Call{
+		Method: "DELETE",
+		URL:    &url.URL{Path: fmt.Sprintf("envelopes/transfer_rules/%s", ruleId)},
+	}).Do(ctx, s)
+}
+
+// TransferRuleForUser creates an enabled transfer rule giving user toUserId
+// ownership of envelopes of user fromUserId, e.g. when an employee leaves.
+// The rule only applies to envelopes as DocuSign processes them after the
+// rule is created; existing envelopes, including those in flight, are not
+// moved.  Remove the rule with EnvelopeTransferRuleDelete when it is no
+// longer needed.
+func (s *Service) TransferRuleForUser(ctx context.Context, fromUserId string, toUserId string) (*EnvelopeTransferRuleList, error) {
+	if fromUserId == "" || toUserId == "" {
+		return nil, fmt.Errorf("docusign: TransferRuleForUser requires from and to user ids")
+	}
+	enabled := DSBool(true)
+	return s.EnvelopeTransferRuleCreate(ctx, &EnvelopeTransferRuleRequest{
+		Enabled:   &enabled,
+		FromUsers: []UserInfo{{UserId: fromUserId}},
+		ToUser:    &UserInfo{UserId: toUserId},
+	})
+}
+
+// EnvelopeDelete deletes draft envelopes by moving them to the recyclebin
+// folder.  An error is returned, and no envelope is moved, if any envelope
+// is not a draft.  Use Void for envelopes that have been sent.
+func (s *Service) EnvelopeDelete(ctx context.Context, envIds ...string) error {
+	if len(envIds) == 0 {
+		return nil
+	}
+	list, err := s.EnvelopeStatusMulti(ctx, envIds...)
+	if err != nil {
+		return err
+	}
+	status := make(map[string]EnvelopeStatus)
+	for _, env := range list {
+		status[env.EnvelopeId] = env.Status
+	}
+	for _, id := range envIds {
+		st, ok := status[id]
+		if !ok {
+			return fmt.Errorf("docusign: envelope %s not found", id)
+		}
+		if st != StatusCreated {
+			return fmt.Errorf("docusign: envelope %s is not a draft (status %s)", id, st)
+		}
+	}
+	return s.EnvelopeMove(ctx, "recyclebin", envIds...)
+}
+
 // AccountCustomFields retrieves a list of envelope custom fields associated with the account.
 //
 // RestApiDocumentation
@@ -928,6 +2646,355 @@ func (s *Service) AccountCustomFields(ctx context.Context) (*CustomFieldList, er
 
 }
 
+// AccountSettings returns the account wide settings as name value pairs,
+// e.g. settings.AccountSettings.Get("allowEnvelopeCorrect").
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Account%20Settings.htm
+func (s *Service) AccountSettings(ctx context.Context) (*AccountSettings, error) {
+	var ret *AccountSettings
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "settings"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// AccountSettingsUpdate changes the listed account settings.  Settings not
+// listed are unchanged.  The credential must be an account administrator.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Account%20Settings.htm
+func (s *Service) AccountSettingsUpdate(ctx context.Context, settings []NmVal) error {
+	return (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: "settings"},
+		Payload: &AccountSettings{AccountSettings: settings},
+	}).Do(ctx, s)
+}
+
+// SignatureProviders returns the signature providers available to the
+// account for use with Seal recipients and RecipientSignatureProviders.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/List%20Signature%20Providers.htm
+func (s *Service) SignatureProviders(ctx context.Context) (*SignatureProviderList, error) {
+	var ret *SignatureProviderList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "signatureProviders"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BrandList returns the brands of the account.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20List%20of%20Brands.htm
+func (s *Service) BrandList(ctx context.Context) (*BrandList, error) {
+	var ret *BrandList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "brands"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BrandGet returns the specified brand.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Brand%20Information.htm
+func (s *Service) BrandGet(ctx context.Context, brandId string) (*Brand, error) {
+	var ret *Brand
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("brands/%s", brandId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BrandCreate creates a new brand for the account.  The returned list
+// contains the new brand.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20Brand.htm
+func (s *Service) BrandCreate(ctx context.Context, brand *Brand) (*BrandList, error) {
+	var ret *BrandList
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "brands"},
+		Payload: brand,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// Brand logo types for BrandLogoPut.
+const (
+	BrandLogoPrimary   = "primary"
+	BrandLogoSecondary = "secondary"
+	BrandLogoEmail     = "email"
+)
+
+// BrandLogoPut replaces the brand's logo of logoType (primary, secondary
+// or email) with the image read from r.  The content type is detected
+// from the image data.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Brand%20Logo%20by%20Type.htm
+func (s *Service) BrandLogoPut(ctx context.Context, brandId string, logoType string, r io.Reader) error {
+	switch logoType {
+	case BrandLogoPrimary, BrandLogoSecondary, BrandLogoEmail:
+	default:
+		return fmt.Errorf("docusign: invalid brand logo type %q", logoType)
+	}
+	// sniff the image type from the first bytes of the logo
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(512)
+	return (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("brands/%s/logos/%s", brandId, logoType)},
+		Payload: &UploadFile{ContentType: http.DetectContentType(head), Data: br},
+	}).Do(ctx, s)
+}
+
+// Brand resource content types for BrandResourcesGet and BrandResourcesPut.
+const (
+	BrandResourceEmail          = "email"
+	BrandResourceSending        = "sending"
+	BrandResourceSigning        = "signing"
+	BrandResourceSigningCaptive = "signing_captive"
+)
+
+// BrandResourceList returns the resource files of a brand.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Brand%20Resources.htm
+func (s *Service) BrandResourceList(ctx context.Context, brandId string) (*BrandResourceList, error) {
+	var ret *BrandResourceList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("brands/%s/resources", brandId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BrandResourcesGet returns the xml resource file of resourceType (email,
+// sending, signing or signing_captive).  Developer is expected to close
+// the http.Response when finished processing.
+// Optional additions: langcode={string}, return_master={true}
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Brand%20Resources%20by%20Content%20Type.htm
+func (s *Service) BrandResourcesGet(ctx context.Context, brandId string, resourceType string, args ...BrandResourceParam) (*http.Response, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *http.Response
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("brands/%s/resources/%s", brandId, resourceType), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BrandResourcesPut uploads the xml resource file of resourceType read
+// from r.  Only elements present in the file are changed.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Brand%20Resources%20by%20Content%20Type.htm
+func (s *Service) BrandResourcesPut(ctx context.Context, brandId string, resourceType string, r io.Reader) (*BrandResource, error) {
+	var ret *BrandResource
+	return ret, (&Call{
+		Method: "PUT",
+		URL:    &url.URL{Path: fmt.Sprintf("brands/%s/resources/%s", brandId, resourceType)},
+		Files:  []*UploadFile{{ContentType: "text/xml", FileName: "file.xml", Id: "1", Data: r}},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type BrandResourceParam NmVal
+
+// BrandResourceLang selects the language of the returned resource file.
+func BrandResourceLang(langCode string) BrandResourceParam {
+	return BrandResourceParam{Name: "langcode", Value: langCode}
+}
+
+// BrandResourceMaster returns the master resource file rather than the
+// brand's modifications.
+var BrandResourceMaster = BrandResourceParam{
+	Name:  "return_master",
+	Value: "true",
+}
+
+// ConsumerDisclosure returns the account's ESIGN consumer disclosure for the
+// language (e.g. "en").
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Consumer%20Disclosure.htm
+func (s *Service) ConsumerDisclosure(ctx context.Context, langCode string) (*ConsumerDisclosure, error) {
+	var ret *ConsumerDisclosure
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("consumer_disclosure/%s", langCode)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// ConsumerDisclosureUpdate sets the account's ESIGN consumer disclosure for the
+// language.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Consumer%20Disclosure.htm
+func (s *Service) ConsumerDisclosureUpdate(ctx context.Context, langCode string, cd *ConsumerDisclosure) (*ConsumerDisclosure, error) {
+	var ret *ConsumerDisclosure
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("consumer_disclosure/%s", langCode)},
+		Payload: cd,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// SigningGroupList returns the signing groups of the account.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Signing%20Groups.htm
+func (s *Service) SigningGroupList(ctx context.Context) (*SigningGroupList, error) {
+	var ret *SigningGroupList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "signing_groups"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// SigningGroupGet returns the specified signing group including its users.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Signing%20Group.htm
+func (s *Service) SigningGroupGet(ctx context.Context, signingGroupId string) (*SigningGroup, error) {
+	var ret *SigningGroup
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("signing_groups/%s", signingGroupId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// SigningGroupCreate creates signing groups.  Check the ErrorDetails of each
+// returned group for failures.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20Signing%20Groups.htm
+func (s *Service) SigningGroupCreate(ctx context.Context, groups *SigningGroupList) (*SigningGroupList, error) {
+	var ret *SigningGroupList
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "signing_groups"},
+		Payload: groups,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// SigningGroupUpdate modifies the name, email and users of a signing group.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Signing%20Group.htm
+func (s *Service) SigningGroupUpdate(ctx context.Context, signingGroupId string, group *SigningGroup) (*SigningGroup, error) {
+	var ret *SigningGroup
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("signing_groups/%s", signingGroupId)},
+		Payload: group,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// WorkspaceList returns the workspaces of the account.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/List%20Workspaces.htm
+func (s *Service) WorkspaceList(ctx context.Context) (*WorkspaceList, error) {
+	var ret *WorkspaceList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "workspaces"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// WorkspaceGet returns the specified workspace.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Workspace.htm
+func (s *Service) WorkspaceGet(ctx context.Context, workspaceId string) (*Workspace, error) {
+	var ret *Workspace
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("workspaces/%s", workspaceId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// WorkspaceCreate creates a new workspace.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20Workspace.htm
+func (s *Service) WorkspaceCreate(ctx context.Context, ws *Workspace) (*Workspace, error) {
+	var ret *Workspace
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "workspaces"},
+		Payload: ws,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// WorkspaceFilePut uploads a file to a workspace folder.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20Workspace%20File.htm
+func (s *Service) WorkspaceFilePut(ctx context.Context, workspaceId string, folderId string, file *UploadFile) (*WorkspaceItem, error) {
+	var ret *WorkspaceItem
+	return ret, (&Call{
+		Method: "POST",
+		URL:    &url.URL{Path: fmt.Sprintf("workspaces/%s/folders/%s/files", workspaceId, folderId)},
+		Files:  []*UploadFile{file},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// WorkspaceFileGet returns the content of a workspace file.  Developer is expected
+// to close the http.Response when finished processing.
+// Optional additions: is_download={true}, pdf_version={true}
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Workspace%20File.htm
+func (s *Service) WorkspaceFileGet(ctx context.Context, workspaceId string, folderId string, fileId string, args ...WorkspaceFileParam) (*http.Response, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *http.Response
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("workspaces/%s/folders/%s/files/%s", workspaceId, folderId, fileId), RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type WorkspaceFileParam NmVal
+
+var WorkspaceFileDownload = WorkspaceFileParam{
+	Name:  "is_download",
+	Value: "true",
+}
+var WorkspaceFilePdfVersion = WorkspaceFileParam{
+	Name:  "pdf_version",
+	Value: "true",
+}
+
 // GetTemplate returns field data for the specified template
 //
 // RestApiDocumentation
@@ -941,6 +3008,264 @@ func (s *Service) GetTemplate(ctx context.Context, id string) (*Template, error)
 	}).Do(ctx, s)
 }
 
+// TemplateCreate adds a template to the account.  Documents may be uploaded
+// by passing files; each file's ID must match a Document's DocumentId.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Post%20Template.htm
+func (s *Service) TemplateCreate(ctx context.Context, tmpl *Template, files ...*UploadFile) (*TemplateResponse, error) {
+	var ret *TemplateResponse
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "templates"},
+		Payload: tmpl,
+		Result:  &ret,
+		Files:   files,
+	}).Do(ctx, s)
+}
+
+// TemplateUpdate modifies an existing template.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Update%20Template.htm
+func (s *Service) TemplateUpdate(ctx context.Context, id string, tmpl *Template) (*TemplateUpdateSummary, error) {
+	var ret *TemplateUpdateSummary
+	return ret, (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("templates/%s", id)},
+		Payload: tmpl,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// TemplateShare shares a template with the groups in shares.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Share%20Template%20with%20Group.htm
+func (s *Service) TemplateShare(ctx context.Context, templateId string, shares *TemplateSharedItems) error {
+	return s.templateGroups(ctx, "PUT", templateId, shares)
+}
+
+// TemplateShareRemove stops sharing a template with the groups in shares.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Remove%20Template%20from%20Group.htm
+func (s *Service) TemplateShareRemove(ctx context.Context, templateId string, shares *TemplateSharedItems) error {
+	return s.templateGroups(ctx, "DELETE", templateId, shares)
+}
+
+// templateGroups modifies the groups sharing a template, returning
+// the first group error reported.
+func (s *Service) templateGroups(ctx context.Context, method string, templateId string, shares *TemplateSharedItems) error {
+	var ret *TemplateSharedItems
+	if err := (&Call{
+		Method:  method,
+		URL:     &url.URL{Path: fmt.Sprintf("templates/%s/groups", templateId)},
+		Payload: shares,
+		Result:  &ret,
+	}).Do(ctx, s); err != nil {
+		return err
+	}
+	for i := 0; ret != nil && i < len(ret.Groups); i++ {
+		if ret.Groups[i].ErrorDetails != nil {
+			return ret.Groups[i].ErrorDetails
+		}
+	}
+	return nil
+}
+
+// TemplateShares returns the groups and users sharing a template as
+// reported by the account's shared access.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Shared%20Access.htm
+func (s *Service) TemplateShares(ctx context.Context, templateId string) (*TemplateSharedItem, error) {
+	var ret *AccountSharedAccess
+	if err := (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "shared_access", RawQuery: "item_type=templates&shared=shared_to"},
+		Result: &ret,
+	}).Do(ctx, s); err != nil {
+		return nil, err
+	}
+	item := &TemplateSharedItem{TemplateId: templateId}
+	for i := 0; ret != nil && i < len(ret.SharedAccess); i++ {
+		for _, t := range ret.SharedAccess[i].Templates {
+			if t.TemplateId != templateId {
+				continue
+			}
+			if t.TemplateName != "" {
+				item.TemplateName, item.Shared, item.Owner = t.TemplateName, t.Shared, t.Owner
+			}
+			item.SharedGroups = append(item.SharedGroups, t.SharedGroups...)
+			item.SharedUsers = append(item.SharedUsers, t.SharedUsers...)
+		}
+	}
+	return item, nil
+}
+
+// UserList returns the users of the account.
+// Optional query strings: count={int}, start_position={int}, email={string},
+// email_substring={string}, status={string}, additional_info={true/false}
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Users%20List.htm
+func (s *Service) UserList(ctx context.Context, args ...UserListParam) (*UserInfoList, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *UserInfoList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "users", RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type UserListParam NmVal
+
+func UserListCount(count int) UserListParam {
+	return UserListParam{Name: "count", Value: strconv.Itoa(count)}
+}
+
+func UserListStartPosition(pos int) UserListParam {
+	return UserListParam{Name: "start_position", Value: strconv.Itoa(pos)}
+}
+
+func UserListEmail(email string) UserListParam {
+	return UserListParam{Name: "email", Value: email}
+}
+
+func UserListEmailSubstring(s string) UserListParam {
+	return UserListParam{Name: "email_substring", Value: s}
+}
+
+func UserListStatus(status string) UserListParam {
+	return UserListParam{Name: "status", Value: status}
+}
+
+var UserListAdditionalInfo = UserListParam{
+	Name:  "additional_info",
+	Value: "true",
+}
+
+// UserGet returns the specified user.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20User%20Information.htm
+func (s *Service) UserGet(ctx context.Context, userId string) (*UserInfo, error) {
+	var ret *UserInfo
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("users/%s", userId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// UserCreate adds new users to the account.  Check the ErrorDetails of each
+// returned user for failures.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Add%20Users%20to%20an%20Account.htm
+func (s *Service) UserCreate(ctx context.Context, users *NewUsersDefinition) (*NewUsersSummary, error) {
+	var ret *NewUsersSummary
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "users"},
+		Payload: users,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// PowerFormList returns the PowerForms of the account.
+// Optional query strings: from_date={dateTime}, to_date={dateTime}
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20PowerForms%20List.htm
+func (s *Service) PowerFormList(ctx context.Context, args ...PowerFormListParam) (*PowerFormList, error) {
+	q := make(url.Values)
+	for _, nv := range args {
+		q.Add(nv.Name, nv.Value)
+	}
+	var ret *PowerFormList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "powerforms", RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+type PowerFormListParam NmVal
+
+func PowerFormListFromDate(t time.Time) PowerFormListParam {
+	return PowerFormListParam{Name: "from_date", Value: DsQueryTimeFormat(t)}
+}
+
+func PowerFormListToDate(t time.Time) PowerFormListParam {
+	return PowerFormListParam{Name: "to_date", Value: DsQueryTimeFormat(t)}
+}
+
+// PowerFormGet returns the specified PowerForm.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20PowerForm.htm
+func (s *Service) PowerFormGet(ctx context.Context, powerFormId string) (*PowerForm, error) {
+	var ret *PowerForm
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: fmt.Sprintf("powerforms/%s", powerFormId)},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// PowerFormCreate creates a PowerForm from a template.  The returned
+// PowerForm contains the PowerFormUrl.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Create%20PowerForm.htm
+func (s *Service) PowerFormCreate(ctx context.Context, pf *PowerForm) (*PowerForm, error) {
+	var ret *PowerForm
+	return ret, (&Call{
+		Method:  "POST",
+		URL:     &url.URL{Path: "powerforms"},
+		Payload: pf,
+		Result:  &ret,
+	}).Do(ctx, s)
+}
+
+// BillingPlan returns the billing plan of the account.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Account%20Billing%20Plan.htm
+func (s *Service) BillingPlan(ctx context.Context) (*BillingPlanInfo, error) {
+	var ret *BillingPlanInfo
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "billing_plan"},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
+// BillingInvoices returns the account invoices issued between from and to.
+// A zero to is omitted from the query.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Billing%20Invoices.htm
+func (s *Service) BillingInvoices(ctx context.Context, from, to time.Time) (*BillingInvoiceList, error) {
+	q := make(url.Values)
+	q.Set("from_date", DsQueryTimeFormat(from))
+	if !to.IsZero() {
+		q.Set("to_date", DsQueryTimeFormat(to))
+	}
+	var ret *BillingInvoiceList
+	return ret, (&Call{
+		Method: "GET",
+		URL:    &url.URL{Path: "billing_invoices", RawQuery: q.Encode()},
+		Result: &ret,
+	}).Do(ctx, s)
+}
+
 // VoidEnvelope voids and existing envelope.
 //
 // RestApiDocumentation
@@ -949,11 +3274,52 @@ func (s *Service) Void(ctx context.Context, envId string, reason string) error {
 	c := &Call{
 		Method:  "PUT",
 		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s", envId)},
-		Payload: map[string]string{"status": "voided", "voidedReason": reason},
+		Payload: map[string]string{"status": StatusVoided, "voidedReason": reason},
 	}
 	return c.Do(ctx, s)
 }
 
+// EnvelopePurge queues the documents (and optionally the metadata) of a completed
+// envelope for purging.  Valid states are listed in the EnvelopePurge* constants.
+//
+// RestApiDocumentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Purge%20Documents%20from%20Completed%20Envelopes.htm
+func (s *Service) EnvelopePurge(ctx context.Context, envId string, state string) error {
+	switch state {
+	case EnvelopePurgeDocuments, EnvelopePurgeDocumentsAndMetadata, EnvelopePurgeDocumentsMetadataAndRedact:
+	default:
+		return fmt.Errorf("docusign: invalid purge state %q", state)
+	}
+	return (&Call{
+		Method:  "PUT",
+		URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s", envId)},
+		Payload: map[string]string{"purgeState": state},
+	}).Do(ctx, s)
+}
+
+const EnvelopePurgeDocuments = "documents_queued"
+const EnvelopePurgeDocumentsAndMetadata = "documents_and_metadata_queued"
+const EnvelopePurgeDocumentsMetadataAndRedact = "documents_and_metadata_and_redact_queued"
+
+// EnvelopeResend resends the envelope notification email to the listed recipients
+// or, when no ids are given, to all recipients who have not yet completed their
+// action.  Unlike Remind, which sends the passed RecipientList (and so may also
+// correct recipient details), EnvelopeResend only identifies recipients by id.
+func (s *Service) EnvelopeResend(ctx context.Context, envId string, recipientIds ...string) error {
+	if len(recipientIds) == 0 {
+		return (&Call{
+			Method:  "PUT",
+			URL:     &url.URL{Path: fmt.Sprintf("envelopes/%s", envId), RawQuery: "resend_envelope=true"},
+			Payload: &Envelope{},
+		}).Do(ctx, s)
+	}
+	rl := &RecipientList{Signers: make([]Signer, len(recipientIds))}
+	for i, id := range recipientIds {
+		rl.Signers[i].RecipientId = id
+	}
+	return s.Remind(ctx, envId, rl)
+}
+
 // Remind sends a reminder to an envelope recipient.
 //
 // RestApiDocumentation
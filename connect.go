@@ -10,19 +10,144 @@
 package docusign
 
 import (
-	"strings"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
 	"time"
+
+	"golang.org/x/net/context"
 )
 
+// ConnectHandler is an http.Handler for a Connect listener.  Each
+// message is decoded using DecodeConnect and passed to Callback (xml
+// messages) or JSONCallback (json messages).  The handler responds with:
+//
+//	200 when the callback succeeds
+//	400 when the message cannot be decoded
+//	401 when the message fails HMAC verification
+//	413 when the message is larger than MaxBodyBytes
+//	415 when no callback is defined for the message format
+//	500 when the callback returns an error, causing docusign to resend the message
+type ConnectHandler struct {
+	Callback     func(context.Context, *ConnectData) error
+	JSONCallback func(context.Context, *ConnectJSONData) error
+	// HMACKeys, when not empty, requires each message to be signed
+	// by one of the keys.  See VerifyConnectHMACKeys.
+	HMACKeys [][]byte
+	// MaxBodyBytes limits the size of a message.  Zero uses
+	// DefaultConnectMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// DefaultConnectMaxBodyBytes is the default message size limit of a
+// ConnectHandler.  Connect messages may include documents, so the limit
+// is generous.
+const DefaultConnectMaxBodyBytes = 32 << 20
+
+// ServeHTTP processes a Connect message.
+func (h *ConnectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	max := h.MaxBodyBytes
+	if max <= 0 {
+		max = DefaultConnectMaxBodyBytes
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, max))
+	if err != nil {
+		if int64(len(body)) >= max {
+			http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(h.HMACKeys) > 0 && !VerifyConnectHMACKeys(body, r.Header, h.HMACKeys...) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	msg, err := DecodeConnect(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch cd := msg.(type) {
+	case *ConnectJSONData:
+		if h.JSONCallback == nil {
+			http.Error(w, "json messages not supported", http.StatusUnsupportedMediaType)
+			return
+		}
+		err = h.JSONCallback(r.Context(), cd)
+	case *ConnectData:
+		if h.Callback == nil {
+			http.Error(w, "xml messages not supported", http.StatusUnsupportedMediaType)
+			return
+		}
+		err = h.Callback(r.Context(), cd)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// DecodeConnect decodes the body of a Connect message.  A json message
+// (Content-Type application/json) is returned as a *ConnectJSONData and
+// any other as a *ConnectData.
+func DecodeConnect(contentType string, body []byte) (interface{}, error) {
+	if mt, _, _ := mime.ParseMediaType(contentType); mt == "application/json" {
+		cd := &ConnectJSONData{}
+		return cd, json.Unmarshal(body, cd)
+	}
+	cd := &ConnectData{}
+	return cd, xml.Unmarshal(body, cd)
+}
+
+// VerifyConnectHMAC returns true if signatureHeader, the value of an
+// X-DocuSign-Signature-N header, is the base64 encoded HMAC-SHA256
+// of the message body using key.
+//
+// Documentation: https://developers.docusign.com/esign-rest-api/guides/connect-hmac
+func VerifyConnectHMAC(body []byte, signatureHeader string, key []byte) bool {
+	sig, err := base64.StdEncoding.DecodeString(signatureHeader)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// VerifyConnectHMACKeys returns true if any of the X-DocuSign-Signature-N
+// headers of a Connect message matches any of the keys.  Use during
+// key rotation when more than one key may be active.
+func VerifyConnectHMACKeys(body []byte, header http.Header, keys ...[]byte) bool {
+	for i := 1; ; i++ {
+		sig := header.Get("X-DocuSign-Signature-" + strconv.Itoa(i))
+		if sig == "" {
+			return false
+		}
+		for _, key := range keys {
+			if VerifyConnectHMAC(body, sig, key) {
+				return true
+			}
+		}
+	}
+}
+
 // DSTime handles the multiple datetime formats that DS returns
-// in the Connect service.
+// in the Connect service and in recipient status fields.
 type DSTime string
 
+// Time returns the parsed time or a zero time if the value is
+// empty or invalid.  Values without a time zone are treated as UTC.
 func (d DSTime) Time() (tm time.Time) {
-	if strings.HasSuffix(string(d), "Z") {
-		tm, _ = time.Parse(time.RFC3339Nano, string(d))
-
-	} else {
+	var err error
+	if tm, err = time.Parse(time.RFC3339Nano, string(d)); err != nil {
 		tm, _ = time.Parse("2006-01-02T15:04:05.999999999", string(d))
 	}
 	return tm
@@ -93,7 +218,7 @@ type RecipientStatusXml struct {
 // DocumentStatusXml describes each document in the envelope.
 type DocumentStatusXml struct {
 	ID           string `xml:"ID" json:"id,omitempty"`
-	Name         string `xml:"Name"RecipientAttachment json:"name,omitempty"`
+	Name         string `xml:"Name" json:"name,omitempty"`
 	TemplateName string `xml:"TemplateName" json:"templateName,omitempty"`
 	Sequence     string `xml:"Sequence" json:"sequence,omitempty"`
 }
@@ -133,3 +258,71 @@ type TabStatusXml struct {
 	ListSelectedValue string `xml:"ListSelectedValue" json:"listSelectedValue,omitempty"`
 	CustomTabType     string `xml:"CustomTabType" json:"customTabType,omitempty"`
 }
+
+// ConnectJSONData is the top level struct for a json formatted
+// Connect message.
+//
+// Documentation: https://developers.docusign.com/platform/webhooks/connect/json-sim-event-model/
+type ConnectJSONData struct {
+	Event             string           `json:"event,omitempty"`
+	APIVersion        string           `json:"apiVersion,omitempty"`
+	URI               string           `json:"uri,omitempty"`
+	RetryCount        json.Number      `json:"retryCount,omitempty"`
+	ConfigurationID   json.Number      `json:"configurationId,omitempty"`
+	GeneratedDateTime DSTime           `json:"generatedDateTime,omitempty"`
+	Data              ConnectEventData `json:"data,omitempty"`
+}
+
+// ConnectEventData identifies the envelope of a json Connect message.
+type ConnectEventData struct {
+	AccountID       string                  `json:"accountId,omitempty"`
+	UserID          string                  `json:"userId,omitempty"`
+	EnvelopeID      string                  `json:"envelopeId,omitempty"`
+	EnvelopeSummary *ConnectEnvelopeSummary `json:"envelopeSummary,omitempty"`
+}
+
+// ConnectEnvelopeSummary contains the envelope information of a json
+// Connect message.
+type ConnectEnvelopeSummary struct {
+	EnvelopeId            string                    `json:"envelopeId,omitempty"`
+	Status                EnvelopeStatus            `json:"status,omitempty"`
+	EmailSubject          string                    `json:"emailSubject,omitempty"`
+	EmailBlurb            string                    `json:"emailBlurb,omitempty"`
+	EnvelopeUri           string                    `json:"envelopeUri,omitempty"`
+	DocumentsUri          string                    `json:"documentsUri,omitempty"`
+	RecipientsUri         string                    `json:"recipientsUri,omitempty"`
+	CustomFieldsUri       string                    `json:"customFieldsUri,omitempty"`
+	CreatedDateTime       DSTime                    `json:"createdDateTime,omitempty"`
+	SentDateTime          DSTime                    `json:"sentDateTime,omitempty"`
+	DeliveredDateTime     DSTime                    `json:"deliveredDateTime,omitempty"`
+	CompletedDateTime     DSTime                    `json:"completedDateTime,omitempty"`
+	DeclinedDateTime      DSTime                    `json:"declinedDateTime,omitempty"`
+	VoidedDateTime        DSTime                    `json:"voidedDateTime,omitempty"`
+	VoidedReason          string                    `json:"voidedReason,omitempty"`
+	StatusChangedDateTime DSTime                    `json:"statusChangedDateTime,omitempty"`
+	Sender                *ConnectSender            `json:"sender,omitempty"`
+	Recipients            *RecipientList            `json:"recipients,omitempty"`
+	EnvelopeDocuments     []ConnectEnvelopeDocument `json:"envelopeDocuments,omitempty"`
+	CustomFields          *CustomFieldList          `json:"customFields,omitempty"`
+}
+
+// ConnectSender describes the sender of the envelope.
+type ConnectSender struct {
+	UserName  string `json:"userName,omitempty"`
+	UserId    string `json:"userId,omitempty"`
+	AccountId string `json:"accountId,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// ConnectEnvelopeDocument describes each document in the envelope.  PDFBytes
+// contains the base64 encoded document when documents are included.
+type ConnectEnvelopeDocument struct {
+	DocumentId     string `json:"documentId,omitempty"`
+	DocumentIdGuid string `json:"documentIdGuid,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Uri            string `json:"uri,omitempty"`
+	Order          string `json:"order,omitempty"`
+	Pages          string `json:"pages,omitempty"`
+	PDFBytes       string `json:"PDFBytes,omitempty"`
+}
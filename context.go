@@ -9,10 +9,13 @@ package docusign
 // Code copied from golang.org/x/oauth2
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"regexp"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -23,6 +26,91 @@ var DefaultCtx = context.Background()
 
 type ctxKeyHTTPClient struct{}
 type ctxKeyLogger struct{}
+type ctxKeyHost struct{}
+type ctxKeyHeaders struct{}
+type ctxKeyActAsUser struct{}
+
+// DemoHost is the host of docusign's demo (sandbox) environment.
+const DemoHost = "demo.docusign.net"
+
+// UseServer returns a context directing calls to host, using client (when not nil)
+// for http requests.  A credential's Host takes precedence over the context host,
+// which takes precedence over DefaultHost.
+func UseServer(ctx context.Context, host string, client *http.Client) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if client != nil {
+		ctx = context.WithValue(ctx, HTTPClient, client)
+	}
+	return context.WithValue(ctx, ctxKeyHost{}, host)
+}
+
+// UseDemoServer returns a context directing calls to the demo environment.
+// See UseServer.
+func UseDemoServer(ctx context.Context, client *http.Client) context.Context {
+	return UseServer(ctx, DemoHost, client)
+}
+
+// contextHost returns the host set by UseServer.
+func contextHost(ctx context.Context) string {
+	host, _ := ctx.Value(ctxKeyHost{}).(string)
+	return host
+}
+
+// WithHeaders returns a context adding hdr to each request made with it,
+// e.g. a correlation id for tracing.  Headers set by previous calls to
+// WithHeaders are kept unless replaced.  Authorization, X-DocuSign-Authentication,
+// X-DocuSign-Act-As-User and Content-Type may not be changed and are ignored.
+func WithHeaders(ctx context.Context, hdr http.Header) context.Context {
+	merged := make(http.Header)
+	for k, v := range contextHeaders(ctx) {
+		merged[k] = v
+	}
+	for k, v := range hdr {
+		merged[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	return context.WithValue(ctx, ctxKeyHeaders{}, merged)
+}
+
+// protectedHeaders may not be set using WithHeaders.
+var protectedHeaders = map[string]bool{
+	"Authorization":             true,
+	"X-Docusign-Authentication": true,
+	"X-Docusign-Act-As-User":    true,
+	"Content-Type":              true,
+}
+
+// contextHeaders returns the headers set by WithHeaders.
+func contextHeaders(ctx context.Context) http.Header {
+	hdr, _ := ctx.Value(ctxKeyHeaders{}).(http.Header)
+	return hdr
+}
+
+// setContextHeaders adds the headers set by WithHeaders to req.
+func setContextHeaders(ctx context.Context, req *http.Request) {
+	for k, v := range contextHeaders(ctx) {
+		if !protectedHeaders[k] {
+			req.Header[k] = v
+		}
+	}
+}
+
+// WithActAsUser returns a context whose calls act on behalf of user, e.g.
+// WithActAsUser(ctx, SoboEmail("user@example.com")).  This allows a single
+// Service to serve many users without cloning it with OnBehalfOf.
+//
+// A Service's own OnBehalfOf user takes precedence over the context user,
+// which is only used when the Service has no on behalf of user.
+func WithActAsUser(ctx context.Context, user SendOnBehalfOf) context.Context {
+	return context.WithValue(ctx, ctxKeyActAsUser{}, user)
+}
+
+// contextActAsUser returns the user set by WithActAsUser.
+func contextActAsUser(ctx context.Context) SendOnBehalfOf {
+	user, _ := ctx.Value(ctxKeyActAsUser{}).(SendOnBehalfOf)
+	return user
+}
 
 // Logger provides a mechanism to log call made via a Service.
 // If a context has the docusign.CallLogger value set to a
@@ -66,9 +154,57 @@ func contextClient(ctx context.Context) *http.Client {
 			return c
 		}
 	}
-	return http.DefaultClient
+	return defaultClient
 }
 
+// DefaultTimeout limits each call and token request made with the package's
+// default client, which is used when neither the Service (see WithHTTPClient)
+// nor the context (see HTTPClient) provides a client.  A context deadline takes
+// precedence and is never shortened.  The timeout includes reading the
+// response body, so raise it or use a context deadline for large
+// downloads.  Zero disables the timeout.
+var DefaultTimeout = 60 * time.Second
+
+// defaultClient is the client used when none is provided.
+var defaultClient = &http.Client{}
+
+// withDefaultTimeout returns ctx limited by DefaultTimeout when calls use
+// the default client and ctx has no deadline.  cancel is nil if ctx is
+// unchanged.
+func withDefaultTimeout(ctx context.Context, client *http.Client) (context.Context, context.CancelFunc) {
+	if client != defaultClient || DefaultTimeout <= 0 {
+		return ctx, nil
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, nil
+	}
+	return context.WithTimeout(ctx, DefaultTimeout)
+}
+
+// cancelBody releases a call's timeout context when the response
+// body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelBody) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// detachedContext keeps the values of a context, such as its client
+// and host, while ignoring its deadline and cancellation.  It is used
+// for cleanup calls that must run after the caller's ctx is done.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
 // contextLogger returns a Logger associated with the
 // provided context.
 func contextLogger(ctx context.Context) Logger {
@@ -78,10 +214,40 @@ func contextLogger(ctx context.Context) Logger {
 	return nil
 }
 
-type SimpleLogger struct{}
+// SimpleLogger logs requests and responses using the standard log package.
+// Authorization headers and access_token, refresh_token and password json
+// fields are masked unless NoRedact is set.
+type SimpleLogger struct {
+	// NoRedact disables masking of credentials.  Only use when debugging
+	// as secrets will be written to the log.
+	NoRedact bool
+}
+
+// redactedHeaders contains the headers masked by SimpleLogger.
+var redactedHeaders = []string{"Authorization", "X-DocuSign-Authentication"}
+
+// redactJSON matches json fields masked by SimpleLogger.
+var redactJSON = regexp.MustCompile(`("(?:access_token|refresh_token|password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
 
 func (s SimpleLogger) LogRequest(ctx context.Context, payload interface{}, req *http.Request) {
-	log.Printf("URL is %s, Payload: %#v", req.URL, payload)
+	if s.NoRedact {
+		log.Printf("URL is %s, Payload: %#v", req.URL, payload)
+		return
+	}
+	hdr := make(http.Header)
+	for k, v := range req.Header {
+		hdr[k] = v
+	}
+	for _, k := range redactedHeaders {
+		if hdr.Get(k) != "" {
+			hdr.Set(k, "REDACTED")
+		}
+	}
+	var p []byte
+	if payload != nil {
+		p, _ = json.Marshal(payload)
+	}
+	log.Printf("URL is %s, Header: %v, Payload: %s", req.URL, hdr, s.redact(p))
 }
 
 func (s SimpleLogger) LogResponse(ctx context.Context, res *http.Response) io.Reader {
@@ -90,6 +256,14 @@ func (s SimpleLogger) LogResponse(ctx context.Context, res *http.Response) io.Re
 		log.Printf("Unable to read response: %v", res.Request.URL)
 		return &bytes.Reader{}
 	}
-	log.Printf("Received %d bytes: %s", res.ContentLength, string(b))
+	log.Printf("Received %d bytes: %s", res.ContentLength, s.redact(b))
 	return bytes.NewReader(b)
 }
+
+// redact masks credential fields in json b.
+func (s SimpleLogger) redact(b []byte) []byte {
+	if s.NoRedact {
+		return b
+	}
+	return redactJSON.ReplaceAll(b, []byte(`$1"REDACTED"`))
+}
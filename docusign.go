@@ -10,14 +10,26 @@ package docusign
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
@@ -27,6 +39,9 @@ import (
 const (
 	Version   = "0.5"
 	userAgent = "docusign-api-go-client/" + Version
+
+	// DefaultHost is used when a credential does not specify a Host.
+	DefaultHost = "www.docusign.net"
 )
 
 /*  Documentation: https://docs.docusign.com/esign/
@@ -46,16 +61,18 @@ EXAMPLES
 	"https://demo.docusign.net/restapi/v2" (sandbox)
 
 */
-var (
-	baseURL = &url.URL{Scheme: "https", User: (*url.Userinfo)(nil), Host: "", Path: "/restapi/v2"}
-)
+const basePath = "/restapi/v2"
 
 // DSBool is used to fix problem of capitalized DSBooleans in json. Unmarshals
-// "True" and "true" as true, any other value returns false
+// "True", "true", true and 1 as true, any other value (including null) returns false
 type DSBool bool
 
 func (d *DSBool) UnmarshalJSON(b []byte) error {
-	*d = DSBool(b[0] == 0x22 && (b[1] == 0x54 || b[1] == 0x74))
+	s := string(b)
+	if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	*d = DSBool(strings.EqualFold(s, "true") || s == "1")
 	return nil
 }
 
@@ -63,15 +80,22 @@ func (d *DSBool) UnmarshalJSON(b []byte) error {
 // the host parameter determines which docusign server(s) to hit
 //   EX: prod north america, prod europe, demo
 // the accountID is used to finish the url's path.
+// An empty host defaults to ref.Host (set from the context by
+// Call.Do) and then to DefaultHost.
 func dsResolveURL(ref *url.URL, host string, accountID string) {
-	baseURL.Host = host
-	ref.Scheme = baseURL.Scheme
-	ref.Host = baseURL.Host
+	if host == "" {
+		host = ref.Host
+	}
+	if host == "" {
+		host = DefaultHost
+	}
+	ref.Scheme = "https"
+	ref.Host = host
 
 	if strings.HasPrefix(ref.Path, "/") {
-		ref.Path = baseURL.Path + ref.Path
+		ref.Path = basePath + ref.Path
 	} else {
-		ref.Path = baseURL.Path + "/accounts/" + accountID + "/" + ref.Path
+		ref.Path = basePath + "/accounts/" + accountID + "/" + ref.Path
 	}
 }
 
@@ -94,6 +118,26 @@ type OauthCredential struct {
 	Scope       string `json:"scope,omitempty"`
 	TokenType   string `json:"token_type,omitempty"`
 	Host        string `json:"host,omitempty"`
+	// ExpiresIn is the lifetime of the token in seconds.  Tokens
+	// from the legacy password grant do not expire and leave
+	// this field zero.
+	ExpiresIn int `json:"expires_in,omitempty"`
+	// Expiry is the time the token expires.  A zero value means
+	// the token does not expire.  omitempty has no effect on a
+	// time.Time, so a zero Expiry is always serialized.
+	Expiry time.Time `json:"expiry"`
+}
+
+// setExpiry calculates Expiry from ExpiresIn.
+func (o *OauthCredential) setExpiry() {
+	if o.ExpiresIn > 0 {
+		o.Expiry = time.Now().Add(time.Duration(o.ExpiresIn) * time.Second)
+	}
+}
+
+// expiresWithin returns true if the token expires within d.
+func (o *OauthCredential) expiresWithin(d time.Duration) bool {
+	return !o.Expiry.IsZero() && time.Now().Add(d).After(o.Expiry)
 }
 
 // Authorize update request with authorization parameters
@@ -126,7 +170,7 @@ func (o OauthCredential) Revoke(ctx context.Context) error {
 	dsResolveURL(req.URL, o.Host, o.AccountId)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	res, err := ctxhttp.Do(ctx, contextClient(ctx), req)
+	res, err := doWithTimeout(ctx, contextClient(ctx), req)
 	if err != nil {
 		return err
 	}
@@ -134,6 +178,81 @@ func (o OauthCredential) Revoke(ctx context.Context) error {
 	return checkResponseStatus(res)
 }
 
+// refreshWindow is the time before expiration that a
+// RefreshingCredential requests a new token.
+const refreshWindow = 60 * time.Second
+
+// RefreshingCredential is a Credential that uses Refresh to obtain
+// a new OauthCredential whenever the current token is within 60
+// seconds of expiring.  It is safe for concurrent use by multiple
+// goroutines.  A failed refresh causes Call.Do to return the refresh
+// error rather than sending a request with a stale token.
+type RefreshingCredential struct {
+	// Refresh returns a new credential (e.g. JWTConfig.Credential).
+	Refresh func(context.Context) (*OauthCredential, error)
+
+	mu   sync.Mutex
+	cred *OauthCredential
+}
+
+// NewRefreshingCredential returns a RefreshingCredential beginning with
+// cred.  If cred is nil, a token is requested on first use.
+func NewRefreshingCredential(cred *OauthCredential, refresh func(context.Context) (*OauthCredential, error)) *RefreshingCredential {
+	return &RefreshingCredential{Refresh: refresh, cred: cred}
+}
+
+// Token returns a current OauthCredential, refreshing if necessary.
+func (r *RefreshingCredential) Token(ctx context.Context) (*OauthCredential, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cred != nil && !r.cred.expiresWithin(refreshWindow) {
+		return r.cred, nil
+	}
+	if r.Refresh == nil {
+		return nil, errors.New("docusign: token expired and no Refresh func defined")
+	}
+	cred, err := r.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.cred = cred
+	return cred, nil
+}
+
+// Authorize update request with authorization parameters.  The
+// Credential interface gives Authorize no way to return an error, so a
+// failed refresh leaves the request unauthorized and the error is lost.
+// Calls made by a Service do not use Authorize and return the refresh
+// error from Call.Do.  Callers authorizing their own requests should
+// call Token first to check for an error.
+func (r *RefreshingCredential) Authorize(req *http.Request, onBehalfOf string) {
+	r.authorize(DefaultCtx, req, onBehalfOf)
+}
+
+func (r *RefreshingCredential) authorize(ctx context.Context, req *http.Request, onBehalfOf string) error {
+	cred, err := r.Token(ctx)
+	if err != nil {
+		return err
+	}
+	cred.Authorize(req, onBehalfOf)
+	return nil
+}
+
+// errAuthorizer is implemented by credentials whose authorization may fail.
+type errAuthorizer interface {
+	authorize(context.Context, *http.Request, string) error
+}
+
+// authorize updates req using cred, returning an error if the
+// credential could not authorize the request.
+func authorize(ctx context.Context, cred Credential, req *http.Request, onBehalfOf string) error {
+	if ea, ok := cred.(errAuthorizer); ok {
+		return ea.authorize(ctx, req, onBehalfOf)
+	}
+	cred.Authorize(req, onBehalfOf)
+	return nil
+}
+
 // Config provides methods to authenticate via a user/password combination.
 // It may also be used to generate an OauthCredential.
 // Documentation:  https://www.docusign.com/p/RESTAPIGuide/RESTAPIGuide.htm#SOBO/Send On Behalf Of Functionality in the DocuSign REST API.htm
@@ -147,11 +266,28 @@ type Config struct {
 	Host          string `json:"host,omitempty"`
 }
 
+// Validate returns an error naming the first required field
+// that is empty.
+func (c *Config) Validate() error {
+	switch {
+	case c.IntegratorKey == "":
+		return errors.New("docusign: Config.IntegratorKey is empty")
+	case c.UserName == "":
+		return errors.New("docusign: Config.UserName is empty")
+	case c.Password == "":
+		return errors.New("docusign: Config.Password is empty")
+	}
+	return nil
+}
+
 // OauthCredential retrieves an OauthCredential  from docusign
 // using the username and password from Config. The returned
 // token does not have a expiration although it may be revoked
 // via
 func (c *Config) OauthCredential(ctx context.Context) (*OauthCredential, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
 	v := url.Values{
 		"grant_type": []string{"password"},
 		"client_id":  []string{c.IntegratorKey},
@@ -168,7 +304,7 @@ func (c *Config) OauthCredential(ctx context.Context) (*OauthCredential, error)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := ctxhttp.Do(ctx, contextClient(ctx), req)
+	res, err := doWithTimeout(ctx, contextClient(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +317,7 @@ func (c *Config) OauthCredential(ctx context.Context) (*OauthCredential, error)
 	if err = json.NewDecoder(res.Body).Decode(&tk); err == nil {
 		tk.Host = c.Host
 		tk.AccountId = c.AccountId
+		tk.setExpiry()
 	}
 	return tk, err
 }
@@ -188,6 +325,12 @@ func (c *Config) OauthCredential(ctx context.Context) (*OauthCredential, error)
 // OauthCredentialOnBehalfOf returns an *OauthCredential for the user name specied by nm.  oauthCred
 // must be a credential for a user with administrative rights on the account.
 func (c *Config) OauthCredentialOnBehalfOf(ctx context.Context, oauthCred OauthCredential, nm string) (*OauthCredential, error) {
+	switch {
+	case c.IntegratorKey == "":
+		return nil, errors.New("docusign: Config.IntegratorKey is empty")
+	case nm == "":
+		return nil, errors.New("docusign: on behalf of user name is empty")
+	}
 	v := url.Values{
 		"grant_type": []string{"password"},
 		"client_id":  []string{c.IntegratorKey},
@@ -202,7 +345,7 @@ func (c *Config) OauthCredentialOnBehalfOf(ctx context.Context, oauthCred OauthC
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	oauthCred.Authorize(req, "")
 
-	res, err := ctxhttp.Do(ctx, contextClient(ctx), req)
+	res, err := doWithTimeout(ctx, contextClient(ctx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +358,7 @@ func (c *Config) OauthCredentialOnBehalfOf(ctx context.Context, oauthCred OauthC
 	if err = json.NewDecoder(res.Body).Decode(&tk); err == nil {
 		tk.Host = c.Host
 		tk.AccountId = c.AccountId
+		tk.setExpiry()
 	}
 	return tk, err
 }
@@ -223,16 +367,151 @@ func (c *Config) OauthCredentialOnBehalfOf(ctx context.Context, oauthCred OauthC
 func (c Config) Authorize(req *http.Request, onBehalfOf string) {
 	dsResolveURL(req.URL, c.Host, c.AccountId)
 	if onBehalfOf != "" {
-		onBehalfOf = "<SendOnBehalfOf>" + onBehalfOf + "</SendOnBehalfOf>"
+		onBehalfOf = "<SendOnBehalfOf>" + xmlEscape(onBehalfOf) + "</SendOnBehalfOf>"
 	}
 	authString := "<DocuSignCredentials>" + onBehalfOf +
-		"<Username>" + c.UserName + "</Username><Password>" +
-		c.Password + "</Password><IntegratorKey>" +
-		c.IntegratorKey + "</IntegratorKey></DocuSignCredentials>"
+		"<Username>" + xmlEscape(c.UserName) + "</Username><Password>" +
+		xmlEscape(c.Password) + "</Password><IntegratorKey>" +
+		xmlEscape(c.IntegratorKey) + "</IntegratorKey></DocuSignCredentials>"
 	req.Header.Set("X-DocuSign-Authentication", authString)
 	return
 }
 
+// xmlEscape returns s with xml special characters escaped.
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// JWTConfig provides methods to obtain an OauthCredential via the
+// OAuth 2.0 JWT grant.  The integrator key must have an RSA keypair
+// and the user must have granted consent for the integrator key.
+//
+// Documentation: https://docs.docusign.com/esign/guide/authentication/oa2_jwt.html
+type JWTConfig struct {
+	// The docusign account used by the login user.  This may be
+	// found using the LoginInformation call.
+	AccountId     string `json:"acctId,omitempty"`
+	IntegratorKey string `json:"key"`
+	// UserID is the guid of the user to impersonate.
+	UserID string `json:"userId"`
+	// PrivateKey is the PEM encoded RSA private key of the integrator key.
+	PrivateKey []byte `json:"privateKey"`
+	// Host is the rest api server (e.g. demo.docusign.net).
+	Host string `json:"host,omitempty"`
+	// AuthHost is the oauth server.  If empty, account-d.docusign.com is
+	// used for demo hosts and account.docusign.com for all others.
+	AuthHost string `json:"authHost,omitempty"`
+	// Scopes defaults to "signature impersonation" when empty.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// jwtExpiration is the lifetime requested for a JWT assertion.
+const jwtExpiration = time.Hour
+
+func (j *JWTConfig) authHost() string {
+	if j.AuthHost != "" {
+		return j.AuthHost
+	}
+	if strings.HasPrefix(j.Host, "demo") {
+		return "account-d.docusign.com"
+	}
+	return "account.docusign.com"
+}
+
+// assertion returns a signed JWT assertion for the token request.
+func (j *JWTConfig) assertion(now time.Time) (string, error) {
+	key, err := parseRSAKey(j.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	scopes := j.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"signature", "impersonation"}
+	}
+	hdr, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   j.IntegratorKey,
+		"sub":   j.UserID,
+		"aud":   j.authHost(),
+		"iat":   now.Unix(),
+		"exp":   now.Add(jwtExpiration).Unix(),
+		"scope": strings.Join(scopes, " "),
+	})
+	if err != nil {
+		return "", err
+	}
+	ss := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	h := sha256.Sum256([]byte(ss))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	if err != nil {
+		return "", err
+	}
+	return ss + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// parseRSAKey decodes a PEM encoded PKCS1 or PKCS8 RSA private key.
+func parseRSAKey(b []byte) (*rsa.PrivateKey, error) {
+	blk, _ := pem.Decode(b)
+	if blk == nil {
+		return nil, errors.New("docusign: private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(blk.Bytes); err == nil {
+		return key, nil
+	}
+	k, err := x509.ParsePKCS8PrivateKey(blk.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("docusign: invalid private key: %v", err)
+	}
+	key, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("docusign: private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// Credential retrieves an OauthCredential from docusign using a
+// signed JWT assertion.  The returned credential expires after
+// ExpiresIn seconds, after which a new credential must be
+// requested.
+func (j *JWTConfig) Credential(ctx context.Context) (*OauthCredential, error) {
+	assertion, err := j.assertion(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequest("POST", "https://"+j.authHost()+"/oauth/token", bytes.NewBufferString(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := doWithTimeout(ctx, contextClient(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	if err = checkResponseStatus(res); err != nil {
+		return nil, err
+	}
+	var tk *OauthCredential
+	if err = json.NewDecoder(res.Body).Decode(&tk); err == nil {
+		tk.Host = j.Host
+		tk.AccountId = j.AccountId
+		tk.setExpiry()
+	}
+	return tk, err
+}
+
 // Upload file describes an a document attachment for uploading
 type UploadFile struct {
 	// mime type of content
@@ -253,6 +532,30 @@ type NmVal struct {
 	Value string `json:"value,omitempty" xml:",chardata"`
 }
 
+// NmVals is a list of name value pairs such as returned by Tabs.Values.
+type NmVals []NmVal
+
+// Map returns the values keyed by name.  If a name appears more than
+// once, the last value wins.
+func (nvs NmVals) Map() map[string]string {
+	m := make(map[string]string, len(nvs))
+	for _, nv := range nvs {
+		m[nv.Name] = nv.Value
+	}
+	return m
+}
+
+// Get returns the value of the named pair.  If a name appears more than
+// once, the last value is returned, matching Map.
+func (nvs NmVals) Get(name string) (string, bool) {
+	for i := len(nvs) - 1; i >= 0; i-- {
+		if nvs[i].Name == name {
+			return nvs[i].Value, true
+		}
+	}
+	return "", false
+}
+
 // ResponseError is generated when docusign returns an http error.
 //
 // Documentation: https://www.docusign.com/p/RESTAPIGuide/RESTAPIGuide.htm#Error Code/Error Code Information.htm
@@ -260,6 +563,13 @@ type ResponseError struct {
 	Err         string `json:"errorCode,omitempty"`
 	Description string `json:"message,omitempty"`
 	Status      int    `json:"-"`
+	// RetryAfter is the wait requested by a Retry-After header.
+	RetryAfter time.Duration `json:"-"`
+	// RateLimitReset and RateLimitRemaining are set from the
+	// X-RateLimit-Reset and X-RateLimit-Remaining headers.  RateLimitRemaining
+	// is only meaningful when RateLimitReset is not zero.
+	RateLimitReset     time.Time `json:"-"`
+	RateLimitRemaining int       `json:"-"`
 }
 
 // UnmarshalJSON allows different versions of response error to be unmarshalled.
@@ -283,20 +593,92 @@ func (r ResponseError) Error() string {
 	return fmt.Sprintf("Status: %d  %s: %s", r.Status, r.Err, r.Description)
 }
 
+// Sentinel errors for use with errors.Is.  A ResponseError matches
+// the sentinel for its category.
+//
+//	if errors.Is(err, docusign.ErrRateLimited) { ... }
+var (
+	ErrRateLimited  = errors.New("docusign: rate limit exceeded")
+	ErrUnavailable  = errors.New("docusign: service unavailable")
+	ErrUnauthorized = errors.New("docusign: authentication failed")
+)
+
+// rateLimitCodes, unavailableCodes and authCodes map DocuSign
+// error codes to error categories.
+var (
+	rateLimitCodes = map[string]bool{
+		"HOURLY_APIINVOCATION_LIMIT_EXCEEDED": true,
+		"BURST_APIINVOCATION_LIMIT_EXCEEDED":  true,
+		"TOO_MANY_REQUESTS":                   true,
+	}
+	unavailableCodes = map[string]bool{
+		"SERVICE_UNAVAILABLE": true,
+		"IN_PROCESS_ENVELOPE": true,
+	}
+	authCodes = map[string]bool{
+		"USER_AUTHENTICATION_FAILED":    true,
+		"PARTNER_AUTHENTICATION_FAILED": true,
+		"AUTHORIZATION_INVALID_TOKEN":   true,
+		"ACCOUNT_LACKS_PERMISSIONS":     true,
+		"USER_LACKS_PERMISSIONS":        true,
+		"invalid_grant":                 true,
+		"invalid_client":                true,
+		"consent_required":              true,
+	}
+)
+
+// IsRateLimited returns true if the error is the result of exceeding
+// the api call limits.
+func (r ResponseError) IsRateLimited() bool {
+	return r.Status == http.StatusTooManyRequests || rateLimitCodes[r.Err]
+}
+
+// IsRetryable returns true if the call may succeed when retried.
+func (r ResponseError) IsRetryable() bool {
+	return r.IsRateLimited() || unavailableCodes[r.Err] ||
+		(r.Status != http.StatusTooManyRequests && retryableStatus(r.Status))
+}
+
+// IsAuthError returns true if the call failed due to invalid
+// credentials or insufficient permissions.
+func (r ResponseError) IsAuthError() bool {
+	return r.Status == http.StatusUnauthorized || authCodes[r.Err]
+}
+
+// Is allows errors.Is to match ErrRateLimited, ErrUnavailable
+// and ErrUnauthorized.
+func (r ResponseError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return r.IsRateLimited()
+	case ErrUnavailable:
+		return r.IsRetryable() && !r.IsRateLimited()
+	case ErrUnauthorized:
+		return r.IsAuthError()
+	}
+	return false
+}
+
 // DsQueryTimeFormat returns a string in the correct format for a querystring format
 func DsQueryTimeFormat(t time.Time) string {
 	return t.Format("01/02/2006 15:04")
 }
 
-// checkResponseStatus looks at the response for a 200 or 201.  If not it will
+// checkResponseStatus looks at the response for a 200, 201 or 206 (range request).  If not it will
 // decode the json into a Response Error.  Returns nil on  success.
 // https://www.docusign.com/p/RESTAPIGuide/RESTAPIGuide.htm#Error Code/Error Code Information.htm
 func checkResponseStatus(res *http.Response) (err error) {
-	if res.StatusCode != 200 && res.StatusCode != 201 {
+	if res.StatusCode != 200 && res.StatusCode != 201 && res.StatusCode != http.StatusPartialContent {
 		re := &ResponseError{Status: res.StatusCode}
-		if res.ContentLength > 0 {
+		re.RetryAfter, _ = retryAfter(res.Header.Get("Retry-After"))
+		if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			re.RateLimitReset = time.Unix(reset, 0)
+			re.RateLimitRemaining, _ = strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+		}
+		// ContentLength is -1 for decompressed and chunked bodies
+		if res.ContentLength != 0 {
 			err = json.NewDecoder(res.Body).Decode(re)
-			if err != nil {
+			if err != nil && err != io.EOF {
 				re.Description = err.Error()
 			}
 		}
@@ -308,21 +690,135 @@ func checkResponseStatus(res *http.Response) (err error) {
 // Service contains all rest methods and stores authorization
 type Service struct {
 	credential Credential
-	onBehalfOf string
+	onBehalfOf SendOnBehalfOf
+	retry      *RetryPolicy
+	// client and logger, when set, are used instead of the context's
+	// client and logger.
+	client *http.Client
+	logger Logger
+}
+
+// httpClient returns the service's client, falling back to
+// the client associated with ctx.
+func (s *Service) httpClient(ctx context.Context) *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return contextClient(ctx)
+}
+
+// callLogger returns the service's logger, falling back to
+// the logger associated with ctx.
+func (s *Service) callLogger(ctx context.Context) Logger {
+	if s.logger != nil {
+		return s.logger
+	}
+	return contextLogger(ctx)
+}
+
+// ServiceOption configures a Service created by New.
+type ServiceOption func(*Service)
+
+// WithHTTPClient sets the client used for all calls of the Service,
+// taking precedence over a client set on the call's context.
+func WithHTTPClient(client *http.Client) ServiceOption {
+	return func(s *Service) {
+		s.client = client
+	}
+}
+
+// WithLogger sets the logger used for all calls of the Service,
+// taking precedence over a logger set on the call's context.
+func WithLogger(logger Logger) ServiceOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// New intializes a new rest api service.  Options such as WithHTTPClient
+// and WithLogger are applied in order.  Without them the client and logger
+// are taken from each call's context, and if the context has no client a
+// default client limited by DefaultTimeout is used.  New panics if
+// credential is nil.
+//
+//	sv := docusign.New(cred, "", docusign.WithHTTPClient(client), docusign.WithLogger(docusign.SimpleLogger{}))
+func New(credential Credential, onBehalfOf string, opts ...ServiceOption) *Service {
+	if credential == nil {
+		panic("docusign: New called with nil credential")
+	}
+	s := &Service{credential: credential, onBehalfOf: SendOnBehalfOf{value: onBehalfOf}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// New intializes a new rest api service.  If client is nil then
-// http.DefaultClient is assumed.
-//func New(ctx context.Context, accountId string, credential Credential) *Service {
-func New(credential Credential, onBehalfOf string) *Service {
-	return &Service{credential: credential, onBehalfOf: onBehalfOf}
+// OnBehalfOf returns a new Service set to authenticate on behalf
+// of user.  The original Service credential must be an administrator.
+// See WithActAsUser to set the user per context instead.
+func (s Service) OnBehalfOf(user SendOnBehalfOf) *Service {
+	s.onBehalfOf = user
+	return &s
+}
+
+// SendOnBehalfOf identifies the user a Service acts for.  Create
+// with SoboEmail or SoboUserId.
+type SendOnBehalfOf struct {
+	value   string
+	isEmail bool
+	isGUID  bool
+}
+
+// SoboEmail identifies a user by email address.
+func SoboEmail(email string) SendOnBehalfOf {
+	return SendOnBehalfOf{value: email, isEmail: true}
+}
+
+// SoboUserId identifies a user by user id (GUID).
+func SoboUserId(userId string) SendOnBehalfOf {
+	return SendOnBehalfOf{value: userId, isGUID: true}
+}
+
+// String returns the email address or user id.
+func (sb SendOnBehalfOf) String() string {
+	return sb.value
+}
+
+// validate checks that the value matches its declared type.
+func (sb SendOnBehalfOf) validate() error {
+	switch {
+	case sb.isEmail && !strings.Contains(sb.value, "@"):
+		return fmt.Errorf("docusign: invalid on behalf of email %q", sb.value)
+	case sb.isGUID && !isGUID(sb.value):
+		return fmt.Errorf("docusign: invalid on behalf of user id %q", sb.value)
+	}
+	return nil
+}
+
+// isGUID returns true if s is formatted as xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func isGUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
 }
 
-// OnBehalfOf returns a new Service set to authenticate then
-// onBehalfOf userId (email address).  The original Service
-// credential must be an administrator.
-func (s Service) OnBehalfOf(onBehalfOf string) *Service {
-	s.onBehalfOf = onBehalfOf
+// WithRetry returns a new Service that retries failed calls
+// according to policy.  A nil policy disables retries.
+func (s Service) WithRetry(policy *RetryPolicy) *Service {
+	s.retry = policy
 	return &s
 }
 
@@ -331,6 +827,8 @@ func (s Service) OnBehalfOf(onBehalfOf string) *Service {
 type Call struct {
 	Method string
 
+	// Payload is encoded as json unless it is an *UploadFile,
+	// in which case the file's Data is sent as the body.
 	Payload interface{}
 	// Result may be either
 	Result interface{}
@@ -338,77 +836,177 @@ type Call struct {
 	Files []*UploadFile
 	// relative url for the call
 	URL *url.URL
+	// additional headers for the call
+	Header http.Header
 }
 
 // Do executes the call.  Response data is encoded into
 // the call's Result.  If Result is a **http.Response, the
-// response is returned without processing.
+// response is returned without processing.  If the Service
+// has a RetryPolicy, failed calls may be retried.
 func (c Call) Do(ctx context.Context, s *Service) error {
+	var raw **http.Response
+	if c.Result != nil {
+		raw, _ = c.Result.(**http.Response)
+	}
+	logger := s.callLogger(ctx)
+
+	for attempt := 1; ; attempt++ {
+		res, err := c.send(ctx, s, raw == nil && c.Result != nil)
+		if err != nil {
+			return err
+		}
+		if err = checkResponseStatus(res); err != nil {
+			res.Body.Close()
+			wait, ok := s.retry.delay(&c, attempt, res)
+			if !ok {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+		if raw != nil {
+			*raw = res
+			return nil
+		}
+
+		defer res.Body.Close()
+		var body io.Reader = res.Body
+		if logger != nil {
+			body = logger.LogResponse(ctx, res)
+		}
+		if c.Result != nil {
+			err = json.NewDecoder(body).Decode(c.Result)
+		}
+		return err
+	}
+}
+
+// send creates and authorizes a new request for the call and
+// returns the response.
+func (c *Call) send(ctx context.Context, s *Service, acceptJSON bool) (*http.Response, error) {
 	var body io.Reader
 	var ct string
-	var raw **http.Response
-	logger := contextLogger(ctx)
 
 	if len(c.Files) > 0 {
 		// formatted body for file upload
-		body, ct = multiBody(c.Payload, c.Files)
+		body, ct = multiBody(ctx, c.Payload, c.Files)
+	} else if f, ok := c.Payload.(*UploadFile); ok {
+		// raw body
+		body, ct = f.Data, f.ContentType
 	} else if c.Payload != nil {
 		// Prepare body
 		b, err := json.Marshal(c.Payload)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		body, ct = bytes.NewReader(b), "application/json"
 	}
 
 	req, err := http.NewRequest(c.Method, "", body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	// copy url as Authorize resolves it in place
+	u := *c.URL
+	if u.Host == "" {
+		u.Host = contextHost(ctx)
+	}
+	req.URL = &u
+	sobo := s.onBehalfOf
+	if sobo.String() == "" {
+		sobo = contextActAsUser(ctx)
+	}
+	if err = sobo.validate(); err == nil {
+		err = authorize(ctx, s.credential, req, sobo.String())
+	}
+	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
 	}
-	req.URL = c.URL
-	s.credential.Authorize(req, s.onBehalfOf)
 	req.Header.Add("User-Agent", userAgent)
 
 	if len(ct) > 0 {
 		req.Header.Set("Content-Type", ct)
 	}
-	if c.Result != nil {
-		if raw, _ = c.Result.(**http.Response); raw == nil {
-			req.Header.Set("accept", "application/json")
-		}
+	if acceptJSON {
+		req.Header.Set("accept", "application/json")
+		// setting Accept-Encoding disables the transport's transparent
+		// decompression, so gzipped bodies are unwrapped below.
+		req.Header.Set("Accept-Encoding", "gzip")
 	}
 
-	if logger != nil {
+	setContextHeaders(ctx, req)
+
+	if logger := s.callLogger(ctx); logger != nil {
 		logger.LogRequest(ctx, c.Payload, req)
 	}
 
-	res, err := ctxhttp.Do(ctx, contextClient(ctx), req)
-	if err != nil {
-		return err
+	res, err := doWithTimeout(ctx, s.httpClient(ctx), req)
+	if err == nil && acceptJSON {
+		err = gunzipResponse(res)
 	}
-	if err = checkResponseStatus(res); err != nil {
-		res.Body.Close()
-		return err
-	}
-	if raw != nil {
-		*raw = res
-		return nil
+	return res, err
+}
+
+// doWithTimeout sends req using client, limited by DefaultTimeout when
+// client is the default client.  The timeout is released when the
+// response body is closed.
+func doWithTimeout(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	ctx, cancel := withDefaultTimeout(ctx, client)
+	res, err := ctxhttp.Do(ctx, client, req)
+	if cancel != nil {
+		if err != nil {
+			cancel()
+		} else {
+			res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+		}
 	}
+	return res, err
+}
 
-	defer res.Body.Close()
-	body = res.Body
-	if logger != nil {
-		body = logger.LogResponse(ctx, res)
+// gunzipResponse replaces a gzip encoded response body with
+// its decompressed content.
+func gunzipResponse(res *http.Response) error {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return nil
 	}
-	if c.Result != nil {
-		err = json.NewDecoder(body).Decode(c.Result)
+	zr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		res.Body.Close()
+		return fmt.Errorf("docusign: invalid gzip response: %v", err)
 	}
-	return err
+	res.Body = &gzipBody{Reader: zr, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return nil
+}
+
+// gzipBody closes both the gzip reader and the underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipBody) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
 }
 
 // multiBody is used to format calls containing files as a multipart/form-data body.
-//
-func multiBody(payload interface{}, files []*UploadFile) (io.Reader, string) {
+// Copying stops when ctx is cancelled and the body returns ctx.Err().
+func multiBody(ctx context.Context, payload interface{}, files []*UploadFile) (io.Reader, string) {
 	pr, pw := io.Pipe()
 	mpw := multipart.NewWriter(pw)
 
@@ -416,8 +1014,11 @@ func multiBody(payload interface{}, files []*UploadFile) (io.Reader, string) {
 		var err error
 		var ptw io.Writer
 		defer func() {
-			if err != nil {
-				pr.CloseWithError(fmt.Errorf("batch: multiPart Error: %v", err))
+			// errors must be set on the writer to be seen by the reader
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				pw.CloseWithError(ctxErr)
+			} else if err != nil {
+				pw.CloseWithError(fmt.Errorf("batch: multiPart Error: %v", err))
 			}
 			// Close input files
 			for _, f := range files {
@@ -443,17 +1044,34 @@ func multiBody(payload interface{}, files []*UploadFile) (io.Reader, string) {
 		}
 
 		for _, f := range files {
+			if err = ctx.Err(); err != nil {
+				return
+			}
 			mh := textproto.MIMEHeader{
 				"Content-Type":        []string{f.ContentType},
 				"Content-Disposition": []string{fmt.Sprintf("file; filename=\"%s\";documentid=%s", f.FileName, f.Id)},
 			}
-			if ptw, err = mpw.CreatePart(mh); err == nil {
-				if _, err = io.Copy(ptw, f.Data); err != nil {
-					break
-				}
+			if ptw, err = mpw.CreatePart(mh); err != nil {
+				return
+			}
+			if _, err = io.Copy(ptw, ctxReader{ctx, f.Data}); err != nil {
+				return
 			}
 		}
 		return
 	}()
 	return pr, "multipart/form-data; boundary=" + mpw.Boundary()
 }
+
+// ctxReader returns ctx.Err() once ctx is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -26,13 +26,36 @@ package docusign
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"log"
+	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -321,7 +344,7 @@ func TestCalls(t *testing.T) {
 		t.Errorf("Recipients Modify Error: %v", err)
 		return
 	}
-	for _, rur := range modRec.recipientUpdateResults {
+	for _, rur := range modRec.RecipientUpdateResults {
 		if rur.ErrorDetails != nil && rur.ErrorDetails.Err == "SUCCESS" {
 			continue
 		}
@@ -633,7 +656,7 @@ func TestMultiBody(t *testing.T) {
 		&UploadFile{Data: newReadCloser("XXXX"), ContentType: "text/plain", FileName: "fn2", Id: "2"},
 		&UploadFile{Data: newReadCloser("XXXX"), ContentType: "text/plain", FileName: "fn3", Id: "3"},
 	}
-	r, ct := multiBody(payload, files)
+	r, ct := multiBody(context.Background(), payload, files)
 	defer r.(io.ReadCloser).Close()
 
 	mpr := multipart.NewReader(r, ct[30:])
@@ -684,3 +707,2963 @@ type byteReadCloser struct {
 func (b byteReadCloser) Close() error {
 	return nil
 }
+
+// testTransport is an http.RoundTripper that passes each request
+// to a handler func rather than the network.
+type testTransport func(*http.Request) (*http.Response, error)
+
+func (t testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t(req)
+}
+
+// testResponse returns a response with a json body.
+func testResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// testContext returns a context whose http client uses fn as a transport.
+func testContext(fn testTransport) context.Context {
+	return context.WithValue(context.Background(), HTTPClient, &http.Client{Transport: fn})
+}
+
+func TestJWTConfig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	cfg := &JWTConfig{
+		AccountId:     "ACCT",
+		IntegratorKey: "KEY",
+		UserID:        "USER",
+		PrivateKey:    pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		Host:          "demo.docusign.net",
+	}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != "https://account-d.docusign.com/oauth/token" {
+			t.Errorf("expected account-d.docusign.com token url; got %s", req.URL)
+		}
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		parts := strings.Split(req.PostForm.Get("assertion"), ".")
+		if len(parts) != 3 {
+			t.Fatalf("expected 3 part assertion; got %d", len(parts))
+		}
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, h[:], sig); err != nil {
+			t.Errorf("invalid assertion signature: %v", err)
+		}
+		var claims map[string]interface{}
+		b, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		if err := json.Unmarshal(b, &claims); err != nil {
+			t.Fatalf("claims unmarshal: %v", err)
+		}
+		if claims["iss"] != "KEY" || claims["sub"] != "USER" || claims["scope"] != "signature impersonation" {
+			t.Errorf("unexpected claims: %v", claims)
+		}
+		return testResponse(req, 200, `{"access_token":"TOKEN","token_type":"Bearer","expires_in":3600}`), nil
+	})
+	cred, err := cfg.Credential(ctx)
+	if err != nil {
+		t.Fatalf("Credential: %v", err)
+	}
+	if cred.AccessToken != "TOKEN" || cred.ExpiresIn != 3600 || cred.AccountId != "ACCT" || cred.Host != "demo.docusign.net" {
+		t.Errorf("unexpected credential: %#v", cred)
+	}
+}
+
+func TestRefreshingCredential(t *testing.T) {
+	var refreshCnt int
+	var refreshErr error
+	rc := NewRefreshingCredential(&OauthCredential{AccessToken: "OLD", Host: "demo.docusign.net", Expiry: time.Now().Add(time.Hour)},
+		func(ctx context.Context) (*OauthCredential, error) {
+			refreshCnt++
+			if refreshErr != nil {
+				return nil, refreshErr
+			}
+			return &OauthCredential{AccessToken: fmt.Sprintf("NEW%d", refreshCnt), Host: "demo.docusign.net", Expiry: time.Now().Add(time.Hour)}, nil
+		})
+	var auth string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		auth = req.Header.Get("Authorization")
+		return testResponse(req, 200, `{}`), nil
+	})
+	sv := New(rc, "")
+	if _, err := sv.EnvelopeStatus(ctx, "ENV"); err != nil || auth != "bearer OLD" || refreshCnt != 0 {
+		t.Errorf("expected bearer OLD with no refresh; got %s %d %v", auth, refreshCnt, err)
+	}
+
+	// expire within refresh window
+	rc.cred.Expiry = time.Now().Add(30 * time.Second)
+	if _, err := sv.EnvelopeStatus(ctx, "ENV"); err != nil || auth != "bearer NEW1" || refreshCnt != 1 {
+		t.Errorf("expected bearer NEW1 with one refresh; got %s %d %v", auth, refreshCnt, err)
+	}
+
+	rc.cred.Expiry = time.Now().Add(-time.Second)
+	refreshErr = errors.New("refresh failed")
+	auth = ""
+	if _, err := sv.EnvelopeStatus(ctx, "ENV"); err != refreshErr || auth != "" {
+		t.Errorf("expected refresh error and no request; got %q %v", auth, err)
+	}
+
+	// Authorize cannot return the error; Token reports it
+	req, _ := http.NewRequest("GET", "/envelopes", nil)
+	rc.Authorize(req, "")
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("expected unauthorized request; got %s", req.Header.Get("Authorization"))
+	}
+	if _, err := rc.Token(context.Background()); err != refreshErr {
+		t.Errorf("Token: expected refresh error; got %v", err)
+	}
+}
+
+func TestSearchStartPosition(t *testing.T) {
+	var query string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		query = req.URL.RawQuery
+		return testResponse(req, 200, `{}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "TOKEN", Host: "demo.docusign.net"}, "")
+	if _, err := sv.FolderEnvSearch(ctx, "FOLDER", FolderEnvSearchStartPosition(20)); err != nil {
+		t.Fatalf("FolderEnvSearch: %v", err)
+	}
+	if query != "start_position=20" {
+		t.Errorf("FolderEnvSearch expected start_position=20; got %s", query)
+	}
+	if _, err := sv.EnvelopeSearch(ctx, SearchFolderCompleted, EnvelopeSearchStartPosition(40)); err != nil {
+		t.Fatalf("EnvelopeSearch: %v", err)
+	}
+	if query != "start_position=40" {
+		t.Errorf("EnvelopeSearch expected start_position=40; got %s", query)
+	}
+}
+
+func TestDSBool(t *testing.T) {
+	tests := []struct {
+		in   string
+		want DSBool
+	}{
+		{"", false},
+		{"null", false},
+		{`""`, false},
+		{`"`, false},
+		{"t", false},
+		{`"true"`, true},
+		{`"True"`, true},
+		{`"false"`, false},
+		{"true", true},
+		{"false", false},
+		{"1", true},
+		{"0", false},
+	}
+	for _, tt := range tests {
+		d := DSBool(!tt.want)
+		if err := d.UnmarshalJSON([]byte(tt.in)); err != nil {
+			t.Errorf("UnmarshalJSON(%q) error: %v", tt.in, err)
+		}
+		if d != tt.want {
+			t.Errorf("UnmarshalJSON(%q) expected %v; got %v", tt.in, tt.want, d)
+		}
+	}
+}
+
+func TestEnvelopePager(t *testing.T) {
+	pages := map[string]string{
+		"start_position=0": `{"nextUri":"/search_folders/completed?start_position=2","folderItems":[{"envelopeId":"1"},{"envelopeId":"2"}]}`,
+		"start_position=2": `{"nextUri":"/search_folders/completed?start_position=4","folderItems":[{"envelopeId":"3"},{"envelopeId":"4"}]}`,
+		"start_position=4": `{"folderItems":[{"envelopeId":"5"}]}`,
+	}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/restapi/v2/accounts/ACCT/search_folders/completed" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		return testResponse(req, 200, pages["start_position="+req.URL.Query().Get("start_position")]), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "TOKEN", AccountId: "ACCT", Host: "demo.docusign.net"}, "")
+
+	for _, tt := range []struct {
+		max   int
+		count int
+		want  string
+	}{
+		{0, 0, "12345"},
+		{3, 0, "123"},
+		{0, 3, "123"},
+	} {
+		args := []SearchFolderParam{EnvelopeSearchStartPosition(0)}
+		if tt.count > 0 {
+			args = append(args, EnvelopeSearchCount(tt.count))
+		}
+		pager := sv.EnvelopeSearchAll(SearchFolderCompleted, args...)
+		if tt.max > 0 {
+			pager.MaxItems = tt.max
+		}
+		var ids string
+		for !pager.Done() {
+			items, err := pager.Next(ctx)
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			for _, itm := range items {
+				ids += itm.EnvelopeId
+			}
+		}
+		if ids != tt.want {
+			t.Errorf("MaxItems %d count %d: expected %s; got %s", tt.max, tt.count, tt.want, ids)
+		}
+	}
+}
+
+func TestBulkRecipientsAdd(t *testing.T) {
+	var body, ct string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(req.Body)
+		body, ct = string(b), req.Header.Get("Content-Type")
+		return testResponse(req, 200, `{"bulkRecipientsCount":"2"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "TOKEN", Host: "demo.docusign.net"}, "")
+	list := &BulkRecipientList{
+		BulkRecipients: []BulkRecipient{
+			{Name: "A Name", Email: "a@example.com", TabLabels: []NmVal{{Name: "Company", Value: "A, Inc."}}},
+			{Name: "B Name", Email: "b@example.com", Note: "note", TabLabels: []NmVal{{Name: "Title", Value: "CEO"}}},
+		},
+	}
+	res, err := sv.BulkRecipientsAdd(ctx, "ENV", "1", list)
+	if err != nil {
+		t.Fatalf("BulkRecipientsAdd: %v", err)
+	}
+	if res.BulkRecipientsCount != "2" {
+		t.Errorf("expected count 2; got %s", res.BulkRecipientsCount)
+	}
+	want := "Name,Email,AccessCode,Identification,Phone,Note,Company,Title\n" +
+		"A Name,a@example.com,,,,,\"A, Inc.\",\n" +
+		"B Name,b@example.com,,,,note,,CEO\n"
+	if ct != "text/csv" || body != want {
+		t.Errorf("expected text/csv %q; got %s %q", want, ct, body)
+	}
+}
+
+func TestJSONTags(t *testing.T) {
+	validName := regexp.MustCompile(`^([A-Za-z0-9_]+|-)$`)
+	checked := make(map[reflect.Type]bool)
+	var check func(reflect.Type)
+	check = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || typ.PkgPath() != reflect.TypeOf(Envelope{}).PkgPath() || checked[typ] {
+			return
+		}
+		checked[typ] = true
+		names := make(map[string]string)
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if !f.Anonymous && f.PkgPath == "" {
+				tag, ok := f.Tag.Lookup("json")
+				nm := strings.Split(tag, ",")[0]
+				if !ok || !validName.MatchString(nm) {
+					t.Errorf("%s.%s has malformed json tag: %s", typ.Name(), f.Name, f.Tag)
+				}
+				if prev, ok := names[nm]; ok && nm != "-" {
+					t.Errorf("%s.%s and %s.%s have the same json name %s", typ.Name(), prev, typ.Name(), f.Name, nm)
+				}
+				names[nm] = f.Name
+			}
+			check(f.Type)
+		}
+	}
+	for _, v := range []interface{}{
+		Envelope{}, Template{}, RecipientList{}, Tabs{}, DocumentFieldList{}, DocumentAssetList{},
+		LoginInfo{}, FolderTemplateList{}, FolderList{}, EnvelopeList{}, AuditEventList{}, TemplateList{},
+		EnvRecipientView{}, ConnectData{}, ConnectJSONData{}, RecipientUpdateResult{}, BulkRecipientList{},
+		OauthCredential{}, LockInfo{}, BrandList{}, TemplateUpdateSummary{}, UserInfoList{}, NewUsersDefinition{},
+		PowerFormList{}, BillingPlanInfo{}, BillingInvoiceList{}, EnvelopeFormData{}, SigningGroupList{}, WorkspaceList{}, WorkspaceItem{},
+		SignatureProviderList{}, TemplateSharedItems{}, AccountSharedAccess{}, BrandResourceList{},
+		EnvelopeTransferRuleList{}, EnvelopeTransferRuleRequest{}, HtmlDefinitionList{}, AccountSettings{},
+	} {
+		check(reflect.TypeOf(v))
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts int
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			res := testResponse(req, 429, `{"errorCode":"HOURLY_APIINVOCATION_LIMIT_EXCEEDED"}`)
+			res.Header.Set("Retry-After", "0")
+			return res, nil
+		}
+		return testResponse(req, 200, `{"envelopeId":"ENV"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "TOKEN", Host: "demo.docusign.net"}, "")
+
+	if _, err := sv.EnvelopeStatus(ctx, "ENV"); err == nil || attempts != 1 {
+		t.Errorf("expected single failed attempt without policy; got %d %v", attempts, err)
+	}
+
+	attempts = 0
+	rsv := sv.WithRetry(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	if env, err := rsv.EnvelopeStatus(ctx, "ENV"); err != nil || attempts != 3 || env.EnvelopeId != "ENV" {
+		t.Errorf("expected success on 3rd attempt; got %d %v", attempts, err)
+	}
+
+	// POST is not idempotent
+	attempts = 0
+	if _, err := rsv.EnvelopeCreate(ctx, &Envelope{}); err == nil || attempts != 1 {
+		t.Errorf("expected POST not to be retried; got %d %v", attempts, err)
+	}
+
+	// PUT may resend emails and is only retried when allowed
+	attempts = 0
+	if err := rsv.EnvelopeResend(ctx, "ENV"); err == nil || attempts != 1 {
+		t.Errorf("expected PUT not to be retried; got %d %v", attempts, err)
+	}
+	attempts = 0
+	if err := sv.WithRetry(&RetryPolicy{MaxAttempts: 3, RetryPutDelete: true}).EnvelopeResend(ctx, "ENV"); err != nil || attempts != 3 {
+		t.Errorf("expected PUT success on 3rd attempt; got %d %v", attempts, err)
+	}
+
+	// cancelled context stops retries
+	attempts = 0
+	var cancel context.CancelFunc
+	var cctx context.Context
+	cctx, cancel = context.WithCancel(testContext(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		cancel()
+		return testResponse(req, 503, ""), nil
+	}))
+	if _, err := rsv.EnvelopeStatus(cctx, "ENV"); err != context.Canceled || attempts != 1 {
+		t.Errorf("expected context.Canceled after 1 attempt; got %d %v", attempts, err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	c := &Call{Method: "GET"}
+	res := testResponse(nil, http.StatusServiceUnavailable, "")
+	res.Header.Set("Retry-After", "7200")
+	if wait, ok := p.delay(c, 1, res); !ok || wait != 10*time.Second {
+		t.Errorf("expected Retry-After clamped to 10s; got %v %v", wait, ok)
+	}
+	res.Header.Set("Retry-After", "3")
+	if wait, ok := p.delay(c, 1, res); !ok || wait != 3*time.Second {
+		t.Errorf("expected Retry-After 3s; got %v %v", wait, ok)
+	}
+	res.Header.Del("Retry-After")
+	if wait, ok := p.delay(c, 4, res); !ok || wait != 8*time.Second {
+		t.Errorf("expected backoff 8s; got %v %v", wait, ok)
+	}
+	if _, ok := p.delay(&Call{Method: "DELETE"}, 1, res); ok {
+		t.Errorf("expected DELETE not to be retried")
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	tabs := &Tabs{
+		TextTabs: []TextTab{{}},
+		ZipTabs:  []ZipTab{{BaseTab: BaseTab{ErrorDetails: &ResponseError{Err: "INVALID_TAB_OPERATION"}}}},
+	}
+	if err := tabs.FirstError(); err == nil || err.(*ResponseError).Err != "INVALID_TAB_OPERATION" {
+		t.Errorf("Tabs expected INVALID_TAB_OPERATION; got %v", err)
+	}
+	if err := (&Tabs{TextTabs: []TextTab{{}}}).FirstError(); err != nil {
+		t.Errorf("Tabs expected nil error; got %v", err)
+	}
+	cfl := &CustomFieldList{
+		TextCustomFields: []CustomField{{Name: "A"}, {Name: "B", ErrorDetails: &ResponseError{Err: "DUPLICATE"}}},
+	}
+	if err := cfl.FirstError(); err == nil || err.(*ResponseError).Err != "DUPLICATE" {
+		t.Errorf("CustomFieldList expected DUPLICATE; got %v", err)
+	}
+	if err := (*Tabs)(nil).FirstError(); err != nil {
+		t.Errorf("nil Tabs expected nil error; got %v", err)
+	}
+	if err := (*CustomFieldList)(nil).FirstError(); err != nil {
+		t.Errorf("nil CustomFieldList expected nil error; got %v", err)
+	}
+}
+
+func TestVerifyConnectHMAC(t *testing.T) {
+	body := []byte("<DocuSignEnvelopeInformation/>")
+	mac := hmac.New(sha256.New, []byte("KEY2"))
+	mac.Write(body)
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if !VerifyConnectHMAC(body, sig, []byte("KEY2")) {
+		t.Errorf("expected valid signature")
+	}
+	if VerifyConnectHMAC(body, sig, []byte("KEY1")) || VerifyConnectHMAC(append(body, ' '), sig, []byte("KEY2")) {
+		t.Errorf("expected invalid signature")
+	}
+	hdr := http.Header{}
+	hdr.Set("X-DocuSign-Signature-1", "invalid")
+	hdr.Set("X-DocuSign-Signature-2", sig)
+	if !VerifyConnectHMACKeys(body, hdr, []byte("KEY1"), []byte("KEY2")) {
+		t.Errorf("expected valid signature for rotated keys")
+	}
+	if VerifyConnectHMACKeys(body, hdr, []byte("KEY1")) {
+		t.Errorf("expected invalid signature for KEY1")
+	}
+}
+
+func TestConnectHandler(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/connect.xml")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	sig := base64.StdEncoding.EncodeToString(hmacSum([]byte("KEY"), body))
+
+	var cbErr error
+	var envId string
+	h := &ConnectHandler{
+		Callback: func(ctx context.Context, cd *ConnectData) error {
+			envId = cd.EnvelopeStatus.EnvelopeID
+			return cbErr
+		},
+		HMACKeys: [][]byte{[]byte("KEY")},
+	}
+	tests := []struct {
+		sig    string
+		body   []byte
+		cbErr  error
+		status int
+	}{
+		{sig, body, nil, http.StatusOK},
+		{sig, body, errors.New("callback error"), http.StatusInternalServerError},
+		{"", body, nil, http.StatusUnauthorized},
+		{base64.StdEncoding.EncodeToString(hmacSum([]byte("KEY"), []byte("<x"))), []byte("<x"), nil, http.StatusBadRequest},
+	}
+	for i, tt := range tests {
+		envId, cbErr = "", tt.cbErr
+		req := httptest.NewRequest("POST", "/connect", bytes.NewReader(tt.body))
+		req.Header.Set("Content-Type", "text/xml")
+		req.Header.Set("X-DocuSign-Signature-1", tt.sig)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("test %d: expected status %d; got %d", i, tt.status, rec.Code)
+		}
+		if tt.status == http.StatusOK && envId == "" {
+			t.Errorf("test %d: expected envelope id", i)
+		}
+	}
+
+	for _, max := range []int64{int64(len(body)), int64(len(body)) - 1} {
+		h.MaxBodyBytes, cbErr = max, nil
+		req := httptest.NewRequest("POST", "/connect", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "text/xml")
+		req.Header.Set("X-DocuSign-Signature-1", sig)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		want := http.StatusOK
+		if max < int64(len(body)) {
+			want = http.StatusRequestEntityTooLarge
+		}
+		if rec.Code != want {
+			t.Errorf("MaxBodyBytes %d: expected status %d; got %d", max, want, rec.Code)
+		}
+	}
+}
+
+func hmacSum(key, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func TestDecodeConnectJSON(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/connect.json")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	msg, err := DecodeConnect("application/json; charset=utf-8", body)
+	if err != nil {
+		t.Fatalf("DecodeConnect: %v", err)
+	}
+	cd, ok := msg.(*ConnectJSONData)
+	if !ok {
+		t.Fatalf("expected *ConnectJSONData; got %T", msg)
+	}
+	sum := cd.Data.EnvelopeSummary
+	switch {
+	case cd.Event != "envelope-completed" || sum == nil:
+		t.Errorf("unexpected message: %#v", cd)
+	case sum.Status != StatusCompleted || sum.CompletedDateTime.Time().IsZero():
+		t.Errorf("unexpected status %s %s", sum.Status, sum.CompletedDateTime)
+	case sum.Recipients == nil || len(sum.Recipients.Signers) != 1 || sum.Recipients.Signers[0].Email != "signer@example.com":
+		t.Errorf("unexpected recipients: %#v", sum.Recipients)
+	case len(sum.EnvelopeDocuments) != 1 || sum.EnvelopeDocuments[0].Name != "Docusign1.pdf":
+		t.Errorf("unexpected documents: %#v", sum.EnvelopeDocuments)
+	case sum.CustomFields == nil || len(sum.CustomFields.TextCustomFields) != 1 || sum.CustomFields.TextCustomFields[0].Value != "123456":
+		t.Errorf("unexpected custom fields: %#v", sum.CustomFields)
+	}
+
+	if msg, err = DecodeConnect("text/xml", []byte("<DocuSignEnvelopeInformation/>")); err != nil {
+		t.Fatalf("DecodeConnect xml: %v", err)
+	}
+	if _, ok := msg.(*ConnectData); !ok {
+		t.Errorf("expected *ConnectData; got %T", msg)
+	}
+}
+
+func TestEmbeddedSigningURL(t *testing.T) {
+	var view EnvRecipientView
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&view); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusCreated, `{"url":"https://demo.docusign.net/Signing/abc"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if _, err := sv.EmbeddedSigningURL(ctx, "env", Signer{}, "https://example.com"); err == nil {
+		t.Errorf("expected error for signer without ClientUserId")
+	}
+	var signer Signer
+	signer.Name, signer.Email, signer.ClientUserId = "Signer", "signer@example.com", "42"
+	u, err := sv.EmbeddedSigningURL(ctx, "env", signer, "https://example.com")
+	if err != nil {
+		t.Fatalf("EmbeddedSigningURL: %v", err)
+	}
+	if u != "https://demo.docusign.net/Signing/abc" {
+		t.Errorf("unexpected url %s", u)
+	}
+	if view.ClientUserId != "42" || view.UserName != "Signer" || view.Email != "signer@example.com" || view.ReturnUrl != "https://example.com" {
+		t.Errorf("unexpected view request %#v", view)
+	}
+}
+
+func TestEnvelopeLock(t *testing.T) {
+	var edit string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/envelopes/env/lock") {
+			return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+		}
+		edit = req.Header.Get("X-DocuSign-Edit")
+		return testResponse(req, http.StatusOK, `{"lockToken":"tok","lockDurationInSeconds":"600","lockType":"edit"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	li, err := sv.EnvelopeLockCreate(ctx, "env", &LockRequest{LockDurationInSeconds: "300", LockType: "edit"})
+	if err != nil {
+		t.Fatalf("EnvelopeLockCreate: %v", err)
+	}
+	if li.LockToken != "tok" || edit != "" {
+		t.Errorf("unexpected create result %#v edit header %q", li, edit)
+	}
+	if _, err = sv.EnvelopeLockUpdate(ctx, "env", li.LockToken, &LockRequest{LockDurationInSeconds: "600"}); err != nil {
+		t.Fatalf("EnvelopeLockUpdate: %v", err)
+	}
+	if edit != `{"LockToken":"tok","LockDurationInSeconds":"600"}` {
+		t.Errorf("unexpected update edit header %q", edit)
+	}
+	if _, err = sv.EnvelopeLockDelete(ctx, "env", li.LockToken); err != nil {
+		t.Fatalf("EnvelopeLockDelete: %v", err)
+	}
+	if edit != `{"LockToken":"tok"}` {
+		t.Errorf("unexpected delete edit header %q", edit)
+	}
+}
+
+func TestTemplateApplyAndView(t *testing.T) {
+	var env Envelope
+	var view EnvRecipientView
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/envelopes"):
+			if err := json.NewDecoder(req.Body).Decode(&env); err != nil {
+				return nil, err
+			}
+			return testResponse(req, http.StatusCreated, `{"envelopeId":"env","status":"sent"}`), nil
+		case strings.HasSuffix(req.URL.Path, "/envelopes/env/views/recipient"):
+			if err := json.NewDecoder(req.Body).Decode(&view); err != nil {
+				return nil, err
+			}
+			return testResponse(req, http.StatusCreated, `{"url":"https://demo.docusign.net/Signing/abc"}`), nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	roles := []TemplateRole{
+		{RoleName: "Witness", Name: "W", Email: "w@example.com"},
+		{RoleName: "Signer", Name: "S", Email: "s@example.com", ClientUserId: "42"},
+	}
+
+	if _, err := sv.TemplateApplyAndView(ctx, "tmpl", roles, &EnvRecipientView{ClientUserId: "7"}); err == nil {
+		t.Errorf("expected error for unmatched ClientUserId")
+	}
+	if env.TemplateId != "" {
+		t.Errorf("envelope created for unmatched ClientUserId")
+	}
+	if _, err := sv.TemplateApplyAndView(ctx, "tmpl", roles, &EnvRecipientView{ClientUserId: "42", AuthenticationMethod: "Retina"}); err == nil {
+		t.Errorf("expected error for unknown AuthenticationMethod")
+	}
+	if env.TemplateId != "" {
+		t.Errorf("envelope created for unknown AuthenticationMethod")
+	}
+	u, err := sv.TemplateApplyAndView(ctx, "tmpl", roles, &EnvRecipientView{ClientUserId: "42"})
+	if err != nil {
+		t.Fatalf("TemplateApplyAndView: %v", err)
+	}
+	if u.Url != "https://demo.docusign.net/Signing/abc" {
+		t.Errorf("unexpected url %s", u.Url)
+	}
+	if env.TemplateId != "tmpl" || env.Status != StatusSent || len(env.TemplateRoles) != 2 {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+	if view.Email != "s@example.com" || view.UserName != "S" || view.AuthenticationMethod != AuthMethodNone {
+		t.Errorf("unexpected view request %#v", view)
+	}
+
+	ctx = testContext(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/envelopes") {
+			return testResponse(req, http.StatusCreated, `{"envelopeId":"env","status":"sent"}`), nil
+		}
+		return testResponse(req, http.StatusBadRequest, `{"errorCode":"UNKNOWN_ENVELOPE_RECIPIENT","message":"bad"}`), nil
+	})
+	if _, err = sv.TemplateApplyAndView(ctx, "tmpl", roles, &EnvRecipientView{ClientUserId: "42"}); err == nil ||
+		!strings.Contains(err.Error(), "envelope env sent") {
+		t.Errorf("expected error with envelope id; got %v", err)
+	}
+}
+
+type testCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (c *testCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestEnvelopeDocumentTo(t *testing.T) {
+	var body *testCloser
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/envelopes/env/documents/2") {
+			return testResponse(req, http.StatusNotFound, `{"errorCode":"NOT_FOUND","message":"not found"}`), nil
+		}
+		res := testResponse(req, http.StatusOK, "%PDF-1.4")
+		body = &testCloser{Reader: res.Body}
+		res.Body = body
+		return res, nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	var buf bytes.Buffer
+	n, err := sv.EnvelopeDocumentTo(ctx, "env", "2", &buf)
+	if err != nil {
+		t.Fatalf("EnvelopeDocumentTo: %v", err)
+	}
+	if n != 8 || buf.String() != "%PDF-1.4" {
+		t.Errorf("expected 8 bytes %%PDF-1.4; got %d %q", n, buf.String())
+	}
+	if body == nil || !body.closed {
+		t.Errorf("response body not closed")
+	}
+	if _, err = sv.EnvelopeDocumentTo(ctx, "env", "3", &buf); err == nil {
+		t.Errorf("expected error for missing document")
+	}
+}
+
+func TestRecipientRemoveById(t *testing.T) {
+	var payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.Method != "DELETE" || !strings.HasSuffix(req.URL.Path, "/envelopes/env/recipients") {
+			return nil, fmt.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		b, err := ioutil.ReadAll(req.Body)
+		payload = string(b)
+		return testResponse(req, http.StatusOK, `{"signers":[{"recipientId":"1"},{"recipientId":"3"}]}`), err
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	rl, err := sv.RecipientRemoveById(ctx, "env", "1", "3")
+	if err != nil {
+		t.Fatalf("RecipientRemoveById: %v", err)
+	}
+	if payload != `{"signers":[{"recipientId":"1"},{"recipientId":"3"}]}` {
+		t.Errorf("unexpected payload %s", payload)
+	}
+	if len(rl.Signers) != 2 || rl.Signers[1].RecipientId != "3" {
+		t.Errorf("unexpected result %#v", rl)
+	}
+}
+
+func TestIDCheckMarshal(t *testing.T) {
+	var signer Signer
+	signer.RecipientId = "1"
+	signer.Name = "Signer"
+	signer.Email = "signer@example.com"
+	signer.ExcludedDocuments = []string{"2", "3"}
+	signer.IdCheckConfigurationName = "ID Check $"
+	signer.RequireIdLookup = true
+	signer.IDCheckInformationInput = &IDCheckInformationInput{
+		DobInformationInput: &DobInformationInput{
+			InformationInput: InformationInput{DisplayLevelCode: "DoNotDisplay", ReceiveInResponse: "true"},
+			DateOfBirth:      "1970-01-02",
+		},
+		Ssn4InformationInput: &Ssn4InformationInput{
+			InformationInput: InformationInput{DisplayLevelCode: "DoNotDisplay"},
+			Ssn4:             "1234",
+		},
+	}
+	env := &Envelope{Recipients: &RecipientList{Signers: []Signer{signer}}}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got struct {
+		Recipients struct {
+			Signers []map[string]json.RawMessage `json:"signers"`
+		} `json:"recipients"`
+	}
+	if err = json.Unmarshal(b, &got); err != nil || len(got.Recipients.Signers) != 1 {
+		t.Fatalf("unexpected json %s: %v", b, err)
+	}
+	s := got.Recipients.Signers[0]
+	expected := map[string]string{
+		"excludedDocuments":        `["2","3"]`,
+		"idCheckConfigurationName": `"ID Check $"`,
+		"requireIdLookup":          `true`,
+		"idCheckInformationInput": `{"dobInformationInput":{"displayLevelCode":"DoNotDisplay","receiveInResponse":"true","dateOfBirth":"1970-01-02"},` +
+			`"ssn4InformationInput":{"displayLevelCode":"DoNotDisplay","ssn4":"1234"}}`,
+	}
+	for k, v := range expected {
+		if string(s[k]) != v {
+			t.Errorf("%s: expected %s; got %s", k, v, s[k])
+		}
+	}
+}
+
+func TestEnvelopeBuilder(t *testing.T) {
+	env, files := NewEnvelopeBuilder("Please sign").
+		EmailBlurb("Contract attached").
+		AddDocument("1", "contract.pdf", strings.NewReader("%PDF-1.4")).
+		AddDocument("2", "terms.html", strings.NewReader("<html></html>")).
+		AddCustomField("PID", "123").
+		AddSigner("a@example.com", "A", 1).
+		SignHere("/sig1/").
+		Text("/addr1/", "Address", "1 Main St").
+		Envelope().
+		AddSigner("b@example.com", "B", 2).
+		ClientUserId("42").
+		SignHere("/sig2/").
+		Envelope().
+		Send().
+		Build()
+
+	if env.EmailSubject != "Please sign" || env.EmailBlurb != "Contract attached" || env.Status != StatusSent {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+	if len(files) != 2 || files[1].Id != "2" || files[1].Order != "2" || !strings.HasPrefix(files[1].ContentType, "text/html") || files[0].ContentType != "application/pdf" {
+		t.Errorf("unexpected files %#v", files)
+	}
+	if len(env.Documents) != 2 || env.Documents[0].DocumentId != "1" || env.Documents[0].Name != "contract.pdf" {
+		t.Errorf("unexpected documents %#v", env.Documents)
+	}
+	if env.CustomFields == nil || len(env.CustomFields.TextCustomFields) != 1 || env.CustomFields.TextCustomFields[0].Value != "123" {
+		t.Errorf("unexpected custom fields %#v", env.CustomFields)
+	}
+	if env.Recipients == nil || len(env.Recipients.Signers) != 2 {
+		t.Fatalf("unexpected recipients %#v", env.Recipients)
+	}
+	a, b := env.Recipients.Signers[0], env.Recipients.Signers[1]
+	if a.RecipientId != "1" || a.RoutingOrder != "1" || a.Email != "a@example.com" || len(a.Tabs.SignHereTabs) != 1 || len(a.Tabs.TextTabs) != 1 {
+		t.Errorf("unexpected first signer %#v", a)
+	}
+	if a.Tabs.TextTabs[0].AnchorString != "/addr1/" || a.Tabs.TextTabs[0].TabLabel != "Address" || a.Tabs.TextTabs[0].Value != "1 Main St" {
+		t.Errorf("unexpected text tab %#v", a.Tabs.TextTabs[0])
+	}
+	if b.RecipientId != "2" || b.RoutingOrder != "2" || b.ClientUserId != "42" || b.Tabs.SignHereTabs[0].AnchorString != "/sig2/" {
+		t.Errorf("unexpected second signer %#v", b)
+	}
+}
+
+func TestAnchorTabs(t *testing.T) {
+	b, err := json.Marshal(SignHere("1", "2", "/sig1/"))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	expected := `{"documentID":"2","anchorString":"/sig1/","anchorUnits":"pixels","anchorXOffset":"0","anchorYOffset":"0","recipientID":"1"}`
+	if string(b) != expected {
+		t.Errorf("expected %s; got %s", expected, b)
+	}
+	tx := Text("1", "", "/addr1/", "Address", "1 Main St")
+	if tx.AnchorString != "/addr1/" || tx.AnchorUnits != "pixels" || tx.TabLabel != "Address" || tx.Value != "1 Main St" || tx.RecipientID != "1" {
+		t.Errorf("unexpected text tab %#v", tx)
+	}
+}
+
+func TestEnvelopePayments(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("include_tabs") != "true" {
+			return nil, fmt.Errorf("expected include_tabs; got %s", req.URL.RawQuery)
+		}
+		return testResponse(req, http.StatusOK, `{"signers":[{"recipientId":"1","tabs":{"formulaTabs":[
+			{"tabLabel":"Total","isPaymentAmount":"true","paymentDetails":{"chargeId":"ch_1","status":"payment_complete",
+			"gatewayAccountId":"gw","currencyCode":"usd","total":{"amountInBaseUnit":"1000","currency":"usd","displayAmount":"10.00"}}},
+			{"tabLabel":"Subtotal","formula":"[a]+[b]"}]}}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	pl, err := sv.EnvelopePayments(ctx, "env")
+	if err != nil {
+		t.Fatalf("EnvelopePayments: %v", err)
+	}
+	if len(pl.Payments) != 1 {
+		t.Fatalf("expected 1 payment; got %#v", pl.Payments)
+	}
+	p := pl.Payments[0]
+	if p.RecipientId != "1" || p.TabLabel != "Total" || p.PaymentId != "ch_1" || p.Status != "payment_complete" ||
+		p.Amount != "10.00" || p.Currency != "usd" || p.GatewayAccountId != "gw" {
+		t.Errorf("unexpected payment %#v", p)
+	}
+}
+
+func TestOnBehalfOf(t *testing.T) {
+	var hdr http.Header
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		hdr = req.Header
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	const guid = "0ba0d798-49ca-43c3-88dc-840d6bcb37af"
+
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	if _, err := sv.OnBehalfOf(SoboUserId(guid)).AccountCustomFields(ctx); err != nil {
+		t.Fatalf("oauth user id: %v", err)
+	}
+	if v := hdr.Get("X-DocuSign-Act-As-User"); v != guid {
+		t.Errorf("oauth user id: expected %s; got %s", guid, v)
+	}
+	if _, err := sv.OnBehalfOf(SoboEmail("a&b@example.com")).AccountCustomFields(ctx); err != nil {
+		t.Fatalf("oauth email: %v", err)
+	}
+	if v := hdr.Get("X-DocuSign-Act-As-User"); v != "a&b@example.com" {
+		t.Errorf("oauth email: expected a&b@example.com; got %s", v)
+	}
+
+	sv = New(&Config{UserName: "u", Password: "p<w>", IntegratorKey: "k", AccountId: "1"}, "")
+	if _, err := sv.OnBehalfOf(SoboEmail("a&b@example.com")).AccountCustomFields(ctx); err != nil {
+		t.Fatalf("legacy email: %v", err)
+	}
+	expected := "<DocuSignCredentials><SendOnBehalfOf>a&amp;b@example.com</SendOnBehalfOf><Username>u</Username>" +
+		"<Password>p&lt;w&gt;</Password><IntegratorKey>k</IntegratorKey></DocuSignCredentials>"
+	if v := hdr.Get("X-DocuSign-Authentication"); v != expected {
+		t.Errorf("legacy email: expected %s; got %s", expected, v)
+	}
+	if _, err := sv.OnBehalfOf(SoboUserId(guid)).AccountCustomFields(ctx); err != nil {
+		t.Fatalf("legacy user id: %v", err)
+	}
+	if v := hdr.Get("X-DocuSign-Authentication"); !strings.HasPrefix(v, "<DocuSignCredentials><SendOnBehalfOf>"+guid+"</SendOnBehalfOf>") {
+		t.Errorf("legacy user id: got %s", v)
+	}
+
+	hdr = nil
+	if _, err := sv.OnBehalfOf(SoboUserId("someone@example.com")).AccountCustomFields(ctx); err == nil || hdr != nil {
+		t.Errorf("expected invalid user id error before sending")
+	}
+	if _, err := sv.OnBehalfOf(SoboEmail(guid)).AccountCustomFields(ctx); err == nil || hdr != nil {
+		t.Errorf("expected invalid email error before sending")
+	}
+}
+
+func TestWithActAsUser(t *testing.T) {
+	var hdr http.Header
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		hdr = req.Header
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if _, err := sv.AccountCustomFields(WithActAsUser(ctx, SoboEmail("ctx@example.com"))); err != nil {
+		t.Fatalf("context user: %v", err)
+	}
+	if v := hdr.Get("X-DocuSign-Act-As-User"); v != "ctx@example.com" {
+		t.Errorf("context user: expected ctx@example.com; got %s", v)
+	}
+	if _, err := sv.OnBehalfOf(SoboEmail("svc@example.com")).AccountCustomFields(WithActAsUser(ctx, SoboEmail("ctx@example.com"))); err != nil {
+		t.Fatalf("service user: %v", err)
+	}
+	if v := hdr.Get("X-DocuSign-Act-As-User"); v != "svc@example.com" {
+		t.Errorf("service user: expected svc@example.com; got %s", v)
+	}
+	if _, err := sv.AccountCustomFields(ctx); err != nil {
+		t.Fatalf("no user: %v", err)
+	}
+	if v, ok := hdr["X-Docusign-Act-As-User"]; ok {
+		t.Errorf("no user: unexpected header %v", v)
+	}
+	hdr = nil
+	if _, err := sv.AccountCustomFields(WithActAsUser(ctx, SoboUserId("ctx@example.com"))); err == nil || hdr != nil {
+		t.Errorf("expected invalid context user id error before sending")
+	}
+}
+
+// zeroReader is an endless reader counting the bytes read.
+type zeroReader struct {
+	n int64
+}
+
+func (z *zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	atomic.AddInt64(&z.n, int64(len(p)))
+	return len(p), nil
+}
+
+func TestMultiBodyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	src := &zeroReader{}
+	r, _ := multiBody(ctx, nil, []*UploadFile{
+		{Data: src, ContentType: "application/pdf", FileName: "big.pdf", Id: "1"},
+	})
+	buf := make([]byte, 32*1024)
+	for i := 0; i < 10; i++ {
+		if _, err := r.Read(buf); err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+	}
+	cancel()
+
+	var err error
+	for i := 0; err == nil && i < 100; i++ {
+		_, err = r.Read(buf)
+	}
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled; got %v", err)
+	}
+	n := atomic.LoadInt64(&src.n)
+	time.Sleep(10 * time.Millisecond)
+	if m := atomic.LoadInt64(&src.n); m != n {
+		t.Errorf("copy continued after cancel: %d bytes read, then %d", n, m)
+	}
+}
+
+func TestSignerDSTime(t *testing.T) {
+	var signer Signer
+	err := json.Unmarshal([]byte(`{"recipientId":"1","sentDateTime":"2020-05-13T15:20:42.067Z",`+
+		`"deliveredDateTime":"2020-05-13T08:21:00.5-07:00","signedDateTime":"2020-05-13T15:22:02.497"}`), &signer)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if signer.SignedDateTime != "2020-05-13T15:22:02.497" {
+		t.Errorf("expected raw value; got %s", signer.SignedDateTime)
+	}
+	sent := time.Date(2020, 5, 13, 15, 20, 42, 67000000, time.UTC)
+	delivered := time.Date(2020, 5, 13, 15, 21, 0, 500000000, time.UTC)
+	signed := time.Date(2020, 5, 13, 15, 22, 2, 497000000, time.UTC)
+	for _, tt := range []struct {
+		fld      string
+		got, exp time.Time
+	}{
+		{"sent", signer.SentDateTime.Time(), sent},
+		{"delivered", signer.DeliveredDateTime.Time(), delivered},
+		{"signed", signer.SignedDateTime.Time(), signed},
+	} {
+		if !tt.got.Equal(tt.exp) {
+			t.Errorf("%s: expected %v; got %v", tt.fld, tt.exp, tt.got)
+		}
+	}
+	if !signer.DeclinedDateTime.Time().IsZero() {
+		t.Errorf("expected zero time for empty DeclinedDateTime")
+	}
+}
+
+func TestRecipientAttachmentJSON(t *testing.T) {
+	ra := RecipientAttachment{Label: "license", AttachmentType: "pdf", Data: "JVBERi0xLjQK"}
+	b, err := json.Marshal(ra)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(b) != `{"label":"license","attachmentType":"pdf","data":"JVBERi0xLjQK"}` {
+		t.Errorf("unexpected json %s", b)
+	}
+	var rb RecipientAttachment
+	if err = json.Unmarshal(b, &rb); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if rb != ra {
+		t.Errorf("expected %#v; got %#v", ra, rb)
+	}
+}
+
+func TestDSResolveURL(t *testing.T) {
+	for _, tt := range []struct {
+		path, host, exp string
+	}{
+		{"envelopes", "", "https://www.docusign.net/restapi/v2/accounts/1/envelopes"},
+		{"envelopes", "demo.docusign.net", "https://demo.docusign.net/restapi/v2/accounts/1/envelopes"},
+		{"/login_information", "eu.docusign.net", "https://eu.docusign.net/restapi/v2/login_information"},
+	} {
+		u := &url.URL{Path: tt.path}
+		dsResolveURL(u, tt.host, "1")
+		if u.String() != tt.exp {
+			t.Errorf("expected %s; got %s", tt.exp, u)
+		}
+	}
+}
+
+func TestAuthorizeConcurrentHosts(t *testing.T) {
+	creds := []*OauthCredential{
+		{AccessToken: "na", AccountId: "1", Host: "na2.docusign.net"},
+		{AccessToken: "eu", AccountId: "2", Host: "eu.docusign.net"},
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, cred := range creds {
+		wg.Add(1)
+		go func(cred *OauthCredential) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				req, _ := http.NewRequest("GET", "", nil)
+				req.URL = &url.URL{Path: "envelopes"}
+				cred.Authorize(req, "")
+				if req.URL.Host != cred.Host || req.URL.Path != "/restapi/v2/accounts/"+cred.AccountId+"/envelopes" {
+					errs <- fmt.Errorf("%s: request resolved to %s", cred.Host, req.URL)
+					return
+				}
+			}
+		}(cred)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestUseServer(t *testing.T) {
+	var host string
+	client := &http.Client{Transport: testTransport(func(req *http.Request) (*http.Response, error) {
+		host = req.URL.Host
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})}
+	ctx := UseDemoServer(nil, client)
+
+	if _, err := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "").AccountCustomFields(ctx); err != nil {
+		t.Fatalf("AccountCustomFields: %v", err)
+	}
+	if host != DemoHost {
+		t.Errorf("expected context host %s; got %s", DemoHost, host)
+	}
+	if _, err := New(&OauthCredential{AccessToken: "x", AccountId: "1", Host: "eu.docusign.net"}, "").AccountCustomFields(ctx); err != nil {
+		t.Fatalf("AccountCustomFields: %v", err)
+	}
+	if host != "eu.docusign.net" {
+		t.Errorf("expected credential host eu.docusign.net; got %s", host)
+	}
+	ctx = UseServer(context.Background(), "na2.docusign.net", nil)
+	if contextHost(ctx) != "na2.docusign.net" || contextClient(ctx) != defaultClient {
+		t.Errorf("unexpected context host %s", contextHost(ctx))
+	}
+}
+
+func TestEnvRecipientViewFrameFields(t *testing.T) {
+	fields := []string{"frameAncestors", "messageOrigins", "pingFrequency", "pingUrl"}
+	decode := func(v *EnvRecipientView) map[string]json.RawMessage {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		m := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(b, &m); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		return m
+	}
+	m := decode(&EnvRecipientView{ClientUserId: "42", ReturnUrl: "https://example.com"})
+	for _, f := range fields {
+		if _, ok := m[f]; ok {
+			t.Errorf("unset %s marshaled", f)
+		}
+	}
+	m = decode(&EnvRecipientView{
+		ClientUserId:   "42",
+		FrameAncestors: []string{"https://app.example.com", "https://apps-d.docusign.com"},
+		MessageOrigins: []string{"https://apps-d.docusign.com"},
+		PingFrequency:  "600",
+		PingUrl:        "https://app.example.com/ping",
+	})
+	expected := map[string]string{
+		"frameAncestors": `["https://app.example.com","https://apps-d.docusign.com"]`,
+		"messageOrigins": `["https://apps-d.docusign.com"]`,
+		"pingFrequency":  `"600"`,
+		"pingUrl":        `"https://app.example.com/ping"`,
+	}
+	for _, f := range fields {
+		if string(m[f]) != expected[f] {
+			t.Errorf("%s: expected %s; got %s", f, expected[f], m[f])
+		}
+	}
+}
+
+func TestRecipientViewAuthMethod(t *testing.T) {
+	var calls int
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return testResponse(req, http.StatusCreated, `{"url":"https://demo.docusign.net/Signing/abc"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	for _, m := range []AuthMethod{"", "kba", "sms", "NotAMethod"} {
+		if _, err := sv.RecipientView(ctx, "env", &EnvRecipientView{ClientUserId: "42", AuthenticationMethod: m}); err == nil {
+			t.Errorf("%q: expected error", m)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("invalid methods: expected no api calls; got %d", calls)
+	}
+	for _, m := range []AuthMethod{AuthMethodNone, AuthMethodKBA, "email", "PASSWORD", "singlesignon_saml"} {
+		if _, err := sv.RecipientView(ctx, "env", &EnvRecipientView{ClientUserId: "42", AuthenticationMethod: m}); err != nil {
+			t.Errorf("%q: %v", m, err)
+		}
+	}
+	if calls != 5 {
+		t.Errorf("valid methods: expected 5 api calls; got %d", calls)
+	}
+}
+
+func TestEnvelopeResend(t *testing.T) {
+	var path, query, payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		path, query, payload = req.URL.Path, req.URL.RawQuery, string(b)
+		return testResponse(req, http.StatusOK, `{}`), err
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if err := sv.EnvelopeResend(ctx, "env"); err != nil {
+		t.Fatalf("EnvelopeResend all: %v", err)
+	}
+	if !strings.HasSuffix(path, "/envelopes/env") || query != "resend_envelope=true" || payload != "{}" {
+		t.Errorf("all: unexpected request %s?%s %s", path, query, payload)
+	}
+	if err := sv.EnvelopeResend(ctx, "env", "1", "2"); err != nil {
+		t.Fatalf("EnvelopeResend scoped: %v", err)
+	}
+	if !strings.HasSuffix(path, "/envelopes/env/recipients") || query != "resend_envelope=true" ||
+		payload != `{"signers":[{"recipientId":"1"},{"recipientId":"2"}]}` {
+		t.Errorf("scoped: unexpected request %s?%s %s", path, query, payload)
+	}
+}
+
+func TestIncludeOpts(t *testing.T) {
+	p := TemplateSearchIncludeOpts(TemplateIncludeRecipients, TemplateIncludeCustomFields)
+	if p.Name != "include" || p.Value != "recipients,custom_fields" {
+		t.Errorf("unexpected template include %#v", p)
+	}
+	if old := TemplateSearchInclude(true, false, false, true, false); old != p {
+		t.Errorf("expected %#v; got %#v", p, old)
+	}
+	rp := RecipientsIncludeOpts(RecipientsOptTabs, RecipientsOptExtended)
+	if len(rp) != 2 || rp[0] != RecipientsIncludeTabs || rp[1] != RecipientsIncludeExtended {
+		t.Errorf("unexpected recipients params %#v", rp)
+	}
+}
+
+func TestEnvelopeAllTabs(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("include_tabs") != "true" {
+			return nil, fmt.Errorf("expected include_tabs; got %s", req.URL.RawQuery)
+		}
+		return testResponse(req, http.StatusOK, `{"signers":[
+			{"recipientId":"1","tabs":{"textTabs":[{"tabLabel":"Address","value":"1 Main St"}]}},
+			{"recipientId":"2"}],
+			"inPersonSigners":[{"recipientId":"3","tabs":{"signHereTabs":[{"tabLabel":"Sig"}]}}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	tabs, err := sv.EnvelopeAllTabs(ctx, "env")
+	if err != nil {
+		t.Fatalf("EnvelopeAllTabs: %v", err)
+	}
+	if len(tabs) != 2 || tabs["1"] == nil || tabs["3"] == nil {
+		t.Fatalf("unexpected tabs %#v", tabs)
+	}
+	if v := tabs["1"].Values(); len(v) != 1 || v[0].Value != "1 Main St" {
+		t.Errorf("unexpected recipient 1 values %#v", v)
+	}
+	if len(tabs["3"].SignHereTabs) != 1 {
+		t.Errorf("unexpected recipient 3 tabs %#v", tabs["3"])
+	}
+}
+
+func TestEnvelopeStatusBatch(t *testing.T) {
+	var inFlight, maxInFlight, singles int32
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if req.Method == "GET" {
+			atomic.AddInt32(&singles, 1)
+			id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			if id == "missing" {
+				return testResponse(req, http.StatusNotFound, `{"errorCode":"ENVELOPE_DOES_NOT_EXIST","message":"not found"}`), nil
+			}
+			return testResponse(req, http.StatusOK, `{"envelopeId":"`+id+`","status":"sent"}`), nil
+		}
+		var body struct {
+			EnvelopeIds []string `json:"envelopeIds"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		if len(body.EnvelopeIds) < maxEnvelopeStatusIds {
+			// reject the short batch so its ids are read individually
+			return testResponse(req, http.StatusBadRequest, `{"errorCode":"INVALID_REQUEST_PARAMETER"}`), nil
+		}
+		var envs []EnvelopeUris
+		for _, id := range body.EnvelopeIds {
+			if id != "0" {
+				envs = append(envs, EnvelopeUris{EnvelopeId: id, Status: "completed"})
+			}
+		}
+		b, _ := json.Marshal(map[string]interface{}{"envelopes": envs})
+		return testResponse(req, http.StatusOK, string(b)), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	ids := make([]string, maxEnvelopeStatusIds+10)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	ids[len(ids)-1] = "missing"
+	res, err := sv.EnvelopeStatusBatch(ctx, ids, 3)
+	errs, ok := err.(EnvelopeStatusErrors)
+	if !ok || len(errs) != 1 || errs["missing"] == nil {
+		t.Fatalf("expected error for missing envelope; got %v", err)
+	}
+	if msg := (EnvelopeStatusErrors{}).Error(); msg != "docusign: status failed for 0 envelope(s)" {
+		t.Errorf("unexpected empty error %q", msg)
+	}
+	if len(res) != len(ids)-1 || res["0"] == nil || res["0"].Status != "sent" || res["1"].Status != "completed" ||
+		res[ids[len(ids)-2]].Status != "sent" {
+		t.Errorf("unexpected results (%d)", len(res))
+	}
+	// id 0 missing from the first batch plus the 10 ids of the failed batch
+	if singles != 11 {
+		t.Errorf("expected 11 individual requests; got %d", singles)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("expected at most 3 concurrent requests; got %d", maxInFlight)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err = sv.EnvelopeStatusBatch(cctx, ids, 3); err != context.Canceled {
+		t.Errorf("expected context.Canceled; got %v", err)
+	}
+
+	// a rate limited batch is not read individually
+	singles = 0
+	ctx = testContext(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "GET" {
+			atomic.AddInt32(&singles, 1)
+		}
+		return testResponse(req, http.StatusTooManyRequests, `{"errorCode":"HOURLY_APIINVOCATION_LIMIT_EXCEEDED"}`), nil
+	})
+	res, err = sv.EnvelopeStatusBatch(ctx, ids, 3)
+	if errs, ok = err.(EnvelopeStatusErrors); !ok || len(errs) != len(ids) || len(res) != 0 {
+		t.Errorf("expected an error for each id; got %d results, %v", len(res), err)
+	}
+	if singles != 0 {
+		t.Errorf("expected no individual requests; got %d", singles)
+	}
+}
+
+func TestEnvelopeStatusMultiBatches(t *testing.T) {
+	var calls int
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		calls++
+		var body struct {
+			EnvelopeIds []string `json:"envelopeIds"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		if len(body.EnvelopeIds) > maxEnvelopeStatusIds {
+			return nil, fmt.Errorf("batch of %d ids exceeds limit", len(body.EnvelopeIds))
+		}
+		// return each batch reversed, omitting "none"
+		var envs []EnvelopeUris
+		for i := len(body.EnvelopeIds) - 1; i >= 0; i-- {
+			if id := body.EnvelopeIds[i]; id != "none" {
+				envs = append(envs, EnvelopeUris{EnvelopeId: id})
+			}
+		}
+		b, _ := json.Marshal(map[string]interface{}{"envelopes": envs})
+		return testResponse(req, http.StatusOK, string(b)), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if res, err := sv.EnvelopeStatusMulti(ctx, "a", "none", "b"); err != nil || len(res) != 2 ||
+		res[0].EnvelopeId != "a" || res[1].EnvelopeId != "b" {
+		t.Errorf("expected results a, b; got %v %v", res, err)
+	}
+	calls = 0
+
+	ids := make([]string, 2*maxEnvelopeStatusIds+5)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	res, err := sv.EnvelopeStatusMulti(ctx, ids...)
+	if err != nil {
+		t.Fatalf("EnvelopeStatusMulti: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 requests; got %d", calls)
+	}
+	if len(res) != len(ids) {
+		t.Fatalf("expected %d results; got %d", len(ids), len(res))
+	}
+	for i := range ids {
+		if res[i].EnvelopeId != ids[i] {
+			t.Fatalf("result %d: expected id %s; got %s", i, ids[i], res[i].EnvelopeId)
+		}
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	gz := func(req *http.Request, status int, body string) *http.Response {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(body))
+		zw.Close()
+		res := testResponse(req, status, buf.String())
+		res.Header.Set("Content-Encoding", "gzip")
+		return res
+	}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Header.Get("accept") == "":
+			if req.Header.Get("Accept-Encoding") != "" {
+				return nil, fmt.Errorf("raw request should not set Accept-Encoding")
+			}
+			return testResponse(req, http.StatusOK, "%PDF"), nil
+		case req.Header.Get("Accept-Encoding") != "gzip":
+			return nil, fmt.Errorf("expected Accept-Encoding gzip; got %q", req.Header.Get("Accept-Encoding"))
+		case strings.HasSuffix(req.URL.Path, "/bad"):
+			return gz(req, http.StatusBadRequest, `{"errorCode":"ENVELOPE_DOES_NOT_EXIST","message":"missing"}`), nil
+		}
+		return gz(req, http.StatusOK, `{"envelopeId":"env","status":"sent"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	env, err := sv.EnvelopeStatus(ctx, "env")
+	if err != nil {
+		t.Fatalf("EnvelopeStatus: %v", err)
+	}
+	if env.EnvelopeId != "env" || env.Status != "sent" {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+
+	_, err = sv.EnvelopeStatus(ctx, "bad")
+	if re, ok := err.(*ResponseError); !ok || re.Err != "ENVELOPE_DOES_NOT_EXIST" {
+		t.Errorf("expected decoded ResponseError; got %#v", err)
+	}
+
+	res, err := sv.EnvelopeDocument(ctx, "env", "1")
+	if err != nil {
+		t.Fatalf("EnvelopeDocument: %v", err)
+	}
+	defer res.Body.Close()
+	if b, _ := ioutil.ReadAll(res.Body); string(b) != "%PDF" {
+		t.Errorf("unexpected raw body %q", b)
+	}
+}
+
+func TestResponseErrorCategories(t *testing.T) {
+	tests := []struct {
+		err         error
+		retryable   bool
+		auth        bool
+		rateLimited bool
+		unavailable bool
+	}{
+		{err: &ResponseError{Status: 429}, retryable: true, rateLimited: true},
+		{err: &ResponseError{Status: 400, Err: "HOURLY_APIINVOCATION_LIMIT_EXCEEDED"}, retryable: true, rateLimited: true},
+		{err: ResponseError{Status: 503}, retryable: true, unavailable: true},
+		{err: &ResponseError{Status: 401, Err: "USER_AUTHENTICATION_FAILED"}, auth: true},
+		{err: &ResponseError{Status: 400, Err: "invalid_grant"}, auth: true},
+		{err: &ResponseError{Status: 400, Err: "ENVELOPE_DOES_NOT_EXIST"}},
+		{err: fmt.Errorf("wrapped: %w", &ResponseError{Status: 429}), retryable: true, rateLimited: true},
+	}
+	for i, tt := range tests {
+		if got := errors.Is(tt.err, ErrRateLimited); got != tt.rateLimited {
+			t.Errorf("%d: errors.Is(ErrRateLimited) = %v", i, got)
+		}
+		if got := errors.Is(tt.err, ErrUnavailable); got != tt.unavailable {
+			t.Errorf("%d: errors.Is(ErrUnavailable) = %v", i, got)
+		}
+		if got := errors.Is(tt.err, ErrUnauthorized); got != tt.auth {
+			t.Errorf("%d: errors.Is(ErrUnauthorized) = %v", i, got)
+		}
+		var re *ResponseError
+		if !errors.As(tt.err, &re) {
+			if v, ok := tt.err.(ResponseError); ok {
+				re = &v
+			} else {
+				t.Fatalf("%d: not a ResponseError", i)
+			}
+		}
+		if re.IsRetryable() != tt.retryable || re.IsAuthError() != tt.auth {
+			t.Errorf("%d: IsRetryable = %v, IsAuthError = %v", i, re.IsRetryable(), re.IsAuthError())
+		}
+	}
+}
+
+func TestCustomFieldListLookup(t *testing.T) {
+	cf := &CustomFieldList{
+		TextCustomFields: []CustomField{{Name: "Dept", Value: "Sales"}},
+		ListCustomFields: []ListCustomField{{CustomField: CustomField{Name: "Region", Value: "East"}}},
+	}
+	if v, ok := cf.Text("Dept"); !ok || v != "Sales" {
+		t.Errorf("Text(Dept) = %q, %v", v, ok)
+	}
+	if _, ok := cf.Text("Region"); ok {
+		t.Errorf("Text(Region) should not find list field")
+	}
+	if l, ok := cf.List("Region"); !ok || l.Value != "East" {
+		t.Errorf("List(Region) = %#v, %v", l, ok)
+	}
+	cf.Set("Dept", "Legal")
+	cf.Set("Case", "123")
+	if len(cf.TextCustomFields) != 2 || cf.TextCustomFields[0].Value != "Legal" || cf.TextCustomFields[1].Value != "123" {
+		t.Errorf("unexpected text fields after Set %#v", cf.TextCustomFields)
+	}
+	var nilList *CustomFieldList
+	if _, ok := nilList.Text("Dept"); ok {
+		t.Errorf("nil list should not find fields")
+	}
+}
+
+func TestAuditEvents(t *testing.T) {
+	var l AuditEventList
+	if err := json.Unmarshal([]byte(`{"auditEvents":[
+		{"eventFields":[{"name":"logTime","value":"2016-05-03T18:01:21.8730000Z"},{"name":"Action","value":"Registered"}]},
+		{"eventFields":[{"name":"logTime","value":"2016-05-03T18:05:02.123"},{"name":"Action","value":"Signed"},{"name":"UserName","value":"Signer"}]},
+		{"eventFields":[{"name":"logTime","value":"bad"},{"name":"Action","value":"Signed"}]}]}`), &l); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	signed := l.Filter("Signed")
+	if len(signed) != 2 {
+		t.Fatalf("expected 2 signed events; got %d", len(signed))
+	}
+	if nm := signed[0].Field("UserName"); nm != "Signer" {
+		t.Errorf("expected UserName Signer; got %q", nm)
+	}
+	if v := signed[0].Field("missing"); v != "" {
+		t.Errorf("expected empty missing field; got %q", v)
+	}
+	tm, err := l.AuditEvents[0].Time()
+	if err != nil || !tm.Equal(time.Date(2016, 5, 3, 18, 1, 21, 873000000, time.UTC)) {
+		t.Errorf("unexpected time %v, %v", tm, err)
+	}
+	if tm, err = signed[0].Time(); err != nil || tm.Minute() != 5 {
+		t.Errorf("unexpected time %v, %v", tm, err)
+	}
+	if _, err = signed[1].Time(); err == nil {
+		t.Errorf("expected error for invalid logTime")
+	}
+}
+
+func TestOfflineAttributes(t *testing.T) {
+	var s Signer
+	if err := json.Unmarshal([]byte(`{"recipientId":"1","offlineAttributes":{"deviceName":"iPad","gpsLatitude":"47.6","gpsLongitude":"-122.3","offlineSigningHash":"abc"}}`), &s); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	oa := s.OfflineAttributes
+	if oa == nil || oa.DeviceName != "iPad" || oa.GpsLatitude != "47.6" || oa.OfflineSigningHash != "abc" {
+		t.Fatalf("unexpected offline attributes %#v", oa)
+	}
+	m := oa.Map()
+	if len(m) != 4 || m["gpsLongitude"] != "-122.3" {
+		t.Errorf("unexpected map %v", m)
+	}
+	if !reflect.DeepEqual(NewOfflineAttributes(m), oa) {
+		t.Errorf("NewOfflineAttributes(%v) = %#v", m, NewOfflineAttributes(m))
+	}
+}
+
+func TestSendTemplate(t *testing.T) {
+	var env Envelope
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		env = Envelope{}
+		if err := json.NewDecoder(req.Body).Decode(&env); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusCreated, `{"envelopeId":"env","status":"`+env.Status+`"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	roles := []TemplateRole{{RoleName: "Signer", Name: "Signer Name", Email: "signer@example.com"}}
+
+	res, err := sv.SendTemplate(ctx, "tmpl", roles)
+	if err != nil {
+		t.Fatalf("SendTemplate: %v", err)
+	}
+	if res.Status != StatusSent || env.TemplateId != "tmpl" || len(env.TemplateRoles) != 1 || env.TemplateRoles[0].RoleName != "Signer" {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+
+	_, err = sv.SendTemplate(ctx, "tmpl", roles, SendTemplateDraft(),
+		SendTemplateEmail("Subject", "Blurb"), SendTemplateCustomField("PID", "123"))
+	if err != nil {
+		t.Fatalf("SendTemplate: %v", err)
+	}
+	if env.Status != StatusCreated || env.EmailSubject != "Subject" || env.EmailBlurb != "Blurb" {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+	if v, ok := env.CustomFields.Text("PID"); !ok || v != "123" {
+		t.Errorf("expected custom field PID; got %#v", env.CustomFields)
+	}
+}
+
+func TestRecipientSignatureProviders(t *testing.T) {
+	s := Signer{
+		EmailRecipient: EmailRecipient{Recipient: Recipient{RecipientId: "1", Name: "Signer"}, Email: "signer@example.eu"},
+		RecipientSignatureProviders: []RecipientSignatureProvider{{
+			SignatureProviderName:    "UniversalSignaturePen_ImageOnly",
+			SignatureProviderOptions: &SignatureProviderOptions{Sms: "+33612345678"},
+		}},
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"name":"Signer","recipientId":"1","email":"signer@example.eu",` +
+		`"recipientSignatureProviders":[{"signatureProviderName":"UniversalSignaturePen_ImageOnly",` +
+		`"signatureProviderOptions":{"sms":"+33612345678"}}]}`
+	if string(b) != want {
+		t.Errorf("expected %s; got %s", want, b)
+	}
+}
+
+func TestNewTestService(t *testing.T) {
+	sv := NewTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/restapi/v2/accounts/test/envelopes" || r.Header.Get("Authorization") != "bearer test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"envelopeId":"env","status":"sent"}`)
+	}))
+	res, err := sv.EnvelopeCreate(context.Background(), &Envelope{Status: StatusSent})
+	if err != nil {
+		t.Fatalf("EnvelopeCreate: %v", err)
+	}
+	if res.EnvelopeId != "env" {
+		t.Errorf("unexpected response %#v", res)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.URL.Path)
+	}))
+	defer srv.Close()
+	sv = New(StaticCredential{BaseURL: srv.URL + "/mock", AccountId: "1"}, "")
+	raw, err := sv.EnvelopeDocument(context.Background(), "env", "1")
+	if err != nil {
+		t.Fatalf("EnvelopeDocument: %v", err)
+	}
+	defer raw.Body.Close()
+	if b, _ := ioutil.ReadAll(raw.Body); string(b) != "/mock/restapi/v2/accounts/1/envelopes/env/documents/1" {
+		t.Errorf("unexpected path %s", b)
+	}
+
+	// a malformed BaseURL never falls back to docusign
+	var sent bool
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		sent = true
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	for _, base := range []string{"", "://bad", "localhost:8080", "/relative"} {
+		sc := StaticCredential{BaseURL: base, AccountId: "1", AccessToken: "test"}
+		if _, err := New(sc, "").EnvelopeStatus(ctx, "env"); err == nil || sent {
+			t.Errorf("BaseURL %q: expected error and no request; got %v", base, err)
+		}
+		req, _ := http.NewRequest("GET", "envelopes", nil)
+		sc.Authorize(req, "")
+		if req.URL.Host != invalidHost || req.Header.Get("Authorization") != "" {
+			t.Errorf("BaseURL %q: expected unauthorized request to %s; got %s %q", base, invalidHost, req.URL, req.Header.Get("Authorization"))
+		}
+	}
+}
+
+func TestNewTestServiceDrainsBody(t *testing.T) {
+	sv := NewTestService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"envelopeId":"env","status":"sent"}`)
+	}))
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		f := &UploadFile{ContentType: "application/pdf", FileName: "a.pdf", Id: "1", Data: strings.NewReader("%PDF")}
+		env := &Envelope{Status: StatusSent, Documents: []Document{{DocumentId: "1", Name: "a.pdf"}}}
+		if _, err := sv.EnvelopeCreate(context.Background(), env, f); err != nil {
+			t.Fatalf("EnvelopeCreate: %v", err)
+		}
+	}
+	// multipart writers exit once their body is drained
+	n := runtime.NumGoroutine()
+	for i := 0; i < 100 && n > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+		n = runtime.NumGoroutine()
+	}
+	if n > before {
+		t.Errorf("expected at most %d goroutines; got %d", before, n)
+	}
+}
+
+func TestReorderRecipients(t *testing.T) {
+	var upd RecipientList
+	var puts int
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "GET" {
+			return testResponse(req, http.StatusOK, `{"signers":[{"recipientId":"1","routingOrder":"1","name":"A"},
+				{"recipientId":"2","routingOrder":"1","name":"B"}],
+				"carbonCopies":[{"recipientId":"3","routingOrder":"2","name":"C"}]}`), nil
+		}
+		puts++
+		upd = RecipientList{}
+		if err := json.NewDecoder(req.Body).Decode(&upd); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusOK, `{"recipientUpdateResults":[{"recipientId":"1"},{"recipientId":"3"}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if _, err := sv.ReorderRecipients(ctx, "env", []string{"3", "9"}); err == nil {
+		t.Errorf("expected error for unknown recipient")
+	}
+	if _, err := sv.ReorderRecipients(ctx, "env", []string{"3", "3"}); err == nil {
+		t.Errorf("expected error for duplicate recipient")
+	}
+	if puts != 0 {
+		t.Fatalf("invalid reorder should not update recipients")
+	}
+	if _, err := sv.ReorderRecipients(ctx, "env", []string{"3", "1"}); err != nil {
+		t.Fatalf("ReorderRecipients: %v", err)
+	}
+	if len(upd.Signers) != 1 || upd.Signers[0].RecipientId != "1" || upd.Signers[0].RoutingOrder != "2" || upd.Signers[0].Name != "" {
+		t.Errorf("unexpected signers %#v", upd.Signers)
+	}
+	if len(upd.CarbonCopies) != 1 || upd.CarbonCopies[0].RoutingOrder != "1" {
+		t.Errorf("unexpected carbon copies %#v", upd.CarbonCopies)
+	}
+}
+
+func TestResponseErrorRateLimitHeaders(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).Truncate(time.Second)
+	res := testResponse(nil, http.StatusTooManyRequests, `{"errorCode":"HOURLY_APIINVOCATION_LIMIT_EXCEEDED","message":"limit"}`)
+	res.Header.Set("Retry-After", "12")
+	res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	res.Header.Set("X-RateLimit-Remaining", "0")
+	re, ok := checkResponseStatus(res).(*ResponseError)
+	if !ok {
+		t.Fatalf("expected *ResponseError")
+	}
+	if re.RetryAfter != 12*time.Second {
+		t.Errorf("expected RetryAfter 12s; got %v", re.RetryAfter)
+	}
+	if !re.RateLimitReset.Equal(reset) || re.RateLimitRemaining != 0 {
+		t.Errorf("unexpected rate limit %v %d", re.RateLimitReset, re.RateLimitRemaining)
+	}
+	if re.Err != "HOURLY_APIINVOCATION_LIMIT_EXCEEDED" {
+		t.Errorf("unexpected error code %s", re.Err)
+	}
+
+	res = testResponse(nil, http.StatusBadRequest, `{"errorCode":"INVALID_REQUEST_BODY"}`)
+	res.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	res.Header.Set("X-RateLimit-Remaining", "999")
+	re = checkResponseStatus(res).(*ResponseError)
+	if re.RetryAfter != 0 || !re.RateLimitReset.IsZero() || re.RateLimitRemaining != 0 {
+		t.Errorf("unexpected rate limit fields %#v", re)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("no request expected for invalid config")
+	})
+	tests := []struct {
+		cfg  Config
+		want string
+	}{
+		{Config{UserName: "u", Password: "p"}, "IntegratorKey"},
+		{Config{IntegratorKey: "k", Password: "p"}, "UserName"},
+		{Config{IntegratorKey: "k", UserName: "u"}, "Password"},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected error naming %s; got %v", tt.want, err)
+		}
+		if _, err := tt.cfg.OauthCredential(ctx); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("OauthCredential: expected error naming %s; got %v", tt.want, err)
+		}
+	}
+	if err := (&Config{IntegratorKey: "k", UserName: "u", Password: "p"}).Validate(); err != nil {
+		t.Errorf("expected valid config; got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected New to panic with nil credential")
+		}
+	}()
+	New(nil, "")
+}
+
+func TestEnvelopeCorrect(t *testing.T) {
+	var calls []string
+	status := "sent"
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		call := req.Method + " " + strings.TrimPrefix(req.URL.Path, "/restapi/v2/accounts/1/envelopes/env")
+		calls = append(calls, call)
+		switch call {
+		case "GET ":
+			return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"`+status+`"}`), nil
+		case "POST /lock":
+			return testResponse(req, http.StatusCreated, `{"lockToken":"tok"}`), nil
+		case "DELETE /lock":
+			return testResponse(req, http.StatusOK, `{}`), nil
+		}
+		if !strings.Contains(req.Header.Get("X-DocuSign-Edit"), `"LockToken":"tok"`) {
+			return nil, fmt.Errorf("%s: missing lock header", call)
+		}
+		if call == "PUT /recipients/2/tabs" {
+			return testResponse(req, http.StatusOK, `{"textTabs":[{"tabId":"t2","errorDetails":{"errorCode":"INVALID_TAB"}}]}`), nil
+		}
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	changes := &CorrectionRequest{
+		Recipients: &RecipientList{Signers: []Signer{{EmailRecipient: EmailRecipient{Recipient: Recipient{RecipientId: "1"}, Email: "new@example.com"}}}},
+		Tabs:       map[string]*Tabs{"1": {TextTabs: []TextTab{{BasePosTab: BasePosTab{TabId: "t1"}, Value: "x"}}}},
+	}
+	if err := sv.EnvelopeCorrect(ctx, "env", changes); err != nil {
+		t.Fatalf("EnvelopeCorrect: %v", err)
+	}
+	want := []string{"GET ", "POST /lock", "PUT /recipients", "PUT /recipients/1/tabs", "DELETE /lock"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected calls %v; got %v", want, calls)
+	}
+
+	calls = nil
+	changes.Tabs["2"] = &Tabs{}
+	if err := sv.EnvelopeCorrect(ctx, "env", changes); err == nil || !strings.Contains(err.Error(), "INVALID_TAB") {
+		t.Errorf("expected tab error; got %v", err)
+	}
+	if calls[len(calls)-1] != "DELETE /lock" {
+		t.Errorf("expected lock released after failure; got %v", calls)
+	}
+
+	calls = nil
+	var cancel context.CancelFunc
+	cctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		call := req.Method + " " + strings.TrimPrefix(req.URL.Path, "/restapi/v2/accounts/1/envelopes/env")
+		calls = append(calls, call)
+		switch call {
+		case "GET ":
+			return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"sent"}`), nil
+		case "POST /lock":
+			return testResponse(req, http.StatusCreated, `{"lockToken":"tok"}`), nil
+		case "PUT /recipients":
+			cancel()
+			return nil, context.Canceled
+		}
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	cctx, cancel = context.WithCancel(cctx)
+	if err := sv.EnvelopeCorrect(cctx, "env", changes); err == nil {
+		t.Errorf("expected error from cancelled correction")
+	}
+	if calls[len(calls)-1] != "DELETE /lock" {
+		t.Errorf("expected lock released after cancel; got %v", calls)
+	}
+	if err := sv.EnvelopeCorrect(ctx, "env", nil); err == nil {
+		t.Errorf("expected error for nil changes")
+	}
+
+	calls, status = nil, "completed"
+	if err := sv.EnvelopeCorrect(ctx, "env", changes); err == nil || !strings.Contains(err.Error(), "cannot be corrected") {
+		t.Errorf("expected not correctable error; got %v", err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected only status call; got %v", calls)
+	}
+}
+
+func TestSimpleLoggerRedact(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	req, _ := http.NewRequest("POST", "https://www.docusign.net/oauth2/token", nil)
+	req.Header.Set("Authorization", "bearer secret-token")
+	res := testResponse(req, http.StatusOK, `{"access_token":"secret-token","token_type":"bearer"}`)
+	payload := map[string]string{"userName": "u", "password": "secret-pwd"}
+
+	var l SimpleLogger
+	l.LogRequest(context.Background(), payload, req)
+	b, _ := ioutil.ReadAll(l.LogResponse(context.Background(), res))
+	if string(b) != `{"access_token":"secret-token","token_type":"bearer"}` {
+		t.Errorf("LogResponse must return the unmodified body; got %s", b)
+	}
+	out := buf.String()
+	if strings.Contains(out, "secret") {
+		t.Errorf("secrets found in log output: %s", out)
+	}
+	if !strings.Contains(out, `"access_token":"REDACTED"`) || !strings.Contains(out, `"userName":"u"`) {
+		t.Errorf("unexpected log output: %s", out)
+	}
+
+	buf.Reset()
+	l.NoRedact = true
+	res = testResponse(req, http.StatusOK, `{"access_token":"secret-token"}`)
+	l.LogResponse(context.Background(), res)
+	if !strings.Contains(buf.String(), "secret-token") {
+		t.Errorf("expected unredacted output; got %s", buf.String())
+	}
+}
+
+func TestEmailSettings(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/envelopes/env/email_settings") {
+			return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+		}
+		var es EmailSetting
+		if err := json.NewDecoder(req.Body).Decode(&es); err != nil {
+			return nil, err
+		}
+		for i := range es.BccEmailAddresses {
+			es.BccEmailAddresses[i].BccEmailAddressId = strconv.Itoa(i + 1)
+		}
+		b, _ := json.Marshal(es)
+		return testResponse(req, http.StatusOK, string(b)), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	es := &EmailSetting{ReplyEmailAddressOverride: "new@example.com"}
+	es.AddBcc("archive@example.com")
+	res, err := sv.EmailSettingsUpdate(ctx, "env", es)
+	if err != nil {
+		t.Fatalf("EmailSettingsUpdate: %v", err)
+	}
+	if res.ReplyEmailAddressOverride != "new@example.com" || len(res.BccEmailAddresses) != 1 ||
+		res.BccEmailAddresses[0].Email != "archive@example.com" || res.BccEmailAddresses[0].BccEmailAddressId != "1" {
+		t.Errorf("unexpected email settings %#v", res)
+	}
+}
+
+func TestNmVals(t *testing.T) {
+	tabs := Tabs{TextTabs: []TextTab{
+		{BaseTab: BaseTab{TabLabel: "Name"}, Value: "first"},
+		{BaseTab: BaseTab{TabLabel: "City"}, Value: "Indianapolis"},
+		{BaseTab: BaseTab{TabLabel: "Name"}, Value: "last"},
+	}}
+	vals := tabs.Values()
+	m := vals.Map()
+	if len(m) != 2 || m["Name"] != "last" || m["City"] != "Indianapolis" {
+		t.Errorf("unexpected map %v", m)
+	}
+	if v, ok := vals.Get("Name"); !ok || v != "last" {
+		t.Errorf("Get(Name) = %q, %v", v, ok)
+	}
+	if _, ok := vals.Get("Zip"); ok {
+		t.Errorf("Get(Zip) should not be found")
+	}
+}
+
+func TestTemplateShare(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "PUT /restapi/v2/accounts/1/templates/tmpl/groups":
+			return testResponse(req, http.StatusOK, `{"groups":[{"groupId":"1"},{"groupId":"2","errorDetails":{"errorCode":"INVALID_GROUP_ID"}}]}`), nil
+		case "GET /restapi/v2/accounts/1/shared_access":
+			return testResponse(req, http.StatusOK, `{"sharedAccess":[
+				{"user":{"userId":"u1"},"templates":[{"templateId":"other"},{"templateId":"tmpl","templateName":"NDA","shared":"shared_to",
+				"sharedGroups":[{"group":{"groupId":"1","groupName":"Sales"},"shared":"shared_to"}]}]},
+				{"user":{"userId":"u2"},"templates":[{"templateId":"tmpl","sharedUsers":[{"user":{"userId":"u3"},"shared":"shared_to"}]}]}]}`), nil
+		}
+		return nil, fmt.Errorf("unexpected call %s %s", req.Method, req.URL.Path)
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	err := sv.TemplateShare(ctx, "tmpl", &TemplateSharedItems{Groups: []Group{{GroupId: "1"}, {GroupId: "2"}}})
+	if re, ok := err.(*ResponseError); !ok || re.Err != "INVALID_GROUP_ID" {
+		t.Errorf("expected group error; got %v", err)
+	}
+	item, err := sv.TemplateShares(ctx, "tmpl")
+	if err != nil {
+		t.Fatalf("TemplateShares: %v", err)
+	}
+	if item.TemplateName != "NDA" || len(item.SharedGroups) != 1 || item.SharedGroups[0].Group.GroupName != "Sales" ||
+		len(item.SharedUsers) != 1 || item.SharedUsers[0].User.UserId != "u3" {
+		t.Errorf("unexpected shared item %#v", item)
+	}
+}
+
+func TestEnvelopeCreateRemoteUrl(t *testing.T) {
+	var parts []string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+		parts = nil
+		mr := multipart.NewReader(req.Body, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, p.Header.Get("Content-Disposition"))
+		}
+		return testResponse(req, http.StatusCreated, `{"envelopeId":"env"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	env := &Envelope{
+		EmailSubject: "Mixed",
+		Documents: []Document{
+			{DocumentId: "2", Name: "remote.pdf", RemoteUrl: "https://example.com/remote.pdf"},
+			{DocumentId: "1", Name: "local.pdf"},
+		},
+	}
+	file := func(id string) *UploadFile {
+		return &UploadFile{ContentType: "application/pdf", FileName: "local.pdf", Id: id, Data: strings.NewReader("%PDF")}
+	}
+	if _, err := sv.EnvelopeCreate(ctx, env, file("1")); err != nil {
+		t.Fatalf("EnvelopeCreate: %v", err)
+	}
+	if len(parts) != 2 || parts[0] != "form-data" || !strings.Contains(parts[1], "documentid=1") {
+		t.Errorf("unexpected parts %q", parts)
+	}
+
+	if _, err := sv.EnvelopeCreate(ctx, env, file("1"), file("2")); err == nil {
+		t.Errorf("expected error for file uploaded for remoteUrl document")
+	}
+	if _, err := sv.EnvelopeCreate(ctx, env); err == nil || !strings.Contains(err.Error(), "document 1") {
+		t.Errorf("expected error for document without content; got %v", err)
+	}
+
+	// files with ids not matching documents are sent in the order given
+	env.Documents = []Document{{DocumentId: "1", Name: "a.pdf"}, {DocumentId: "2", Name: "b.pdf"}}
+	if _, err := sv.EnvelopeCreate(ctx, env, file(""), file("")); err != nil {
+		t.Fatalf("EnvelopeCreate positional: %v", err)
+	}
+	if len(parts) != 3 || !strings.HasSuffix(parts[1], "documentid=") || !strings.HasSuffix(parts[2], "documentid=") {
+		t.Errorf("positional: unexpected parts %q", parts)
+	}
+	if _, err := sv.EnvelopeCreate(ctx, env, file("2"), file("x")); err != nil {
+		t.Fatalf("EnvelopeCreate mismatched ids: %v", err)
+	}
+	if len(parts) != 3 || !strings.Contains(parts[1], "documentid=2") || !strings.Contains(parts[2], "documentid=x") {
+		t.Errorf("mismatched ids: unexpected parts %q", parts)
+	}
+}
+
+func TestNotificationDays(t *testing.T) {
+	n := Notification{Reminders: NewReminder(2, 3), Expirations: NewExpiration(30, 5)}
+	b, _ := json.Marshal(n)
+	want := `{"reminders":{"reminderEnabled":"true","reminderDelay":"2","reminderFrequency":"3"},` +
+		`"expirations":{"expireEnabled":"true","expireAfter":"30","expireWarn":"5"}}`
+	if string(b) != want {
+		t.Errorf("expected %s; got %s", want, b)
+	}
+	if d, err := n.Reminders.DelayDays(); err != nil || d != 2 {
+		t.Errorf("DelayDays = %d, %v", d, err)
+	}
+	if d, err := n.Expirations.AfterDays(); err != nil || d != 30 {
+		t.Errorf("AfterDays = %d, %v", d, err)
+	}
+	if d, err := (Expiration{}).WarnDays(); err != nil || d != 0 {
+		t.Errorf("empty WarnDays = %d, %v", d, err)
+	}
+	if _, err := (Reminder{ReminderFrequency: "weekly"}).FrequencyDays(); err == nil {
+		t.Errorf("expected error for invalid frequency")
+	}
+}
+
+func TestDocumentHtmlDefinition(t *testing.T) {
+	var env Envelope
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+			return nil, fmt.Errorf("expected json body; got %s", ct)
+		}
+		if err := json.NewDecoder(req.Body).Decode(&env); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusCreated, `{"envelopeId":"env"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	doc := Document{
+		DocumentId:     "1",
+		Name:           "agreement.html",
+		FileExtension:  "html",
+		DocumentBase64: []byte("<html><body>Agree</body></html>"),
+		HtmlDefinition: &DocumentHtmlDefinition{
+			Source: "document",
+			DisplayAnchors: []DocumentHtmlDisplayAnchor{{
+				StartAnchor:     "terms_start",
+				EndAnchor:       "terms_end",
+				DisplaySettings: &DocumentHtmlDisplaySettings{Display: "collapsible", DisplayLabel: "Terms"},
+			}},
+		},
+	}
+	b, _ := json.Marshal(doc)
+	if !strings.Contains(string(b), `"htmlDefinition":{"source":"document","displayAnchors":[{"displaySettings":{"display":"collapsible","displayLabel":"Terms"},"endAnchor":"terms_end","startAnchor":"terms_start"}]}`) {
+		t.Errorf("unexpected json %s", b)
+	}
+	if _, err := sv.EnvelopeCreate(ctx, &Envelope{Documents: []Document{doc}}); err != nil {
+		t.Fatalf("EnvelopeCreate: %v", err)
+	}
+	if len(env.Documents) != 1 || !reflect.DeepEqual(env.Documents[0], doc) {
+		t.Errorf("unexpected documents %#v", env.Documents)
+	}
+}
+
+func TestDocumentHtmlDefinitions(t *testing.T) {
+	var path string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		path = req.URL.Path
+		return testResponse(req, http.StatusOK, `{"htmlDefinitions":[{"documentId":"1","documentIdGuid":"g1",`+
+			`"htmlDefinition":{"source":"document","displayAnchors":[{"startAnchor":"s","endAnchor":"e"}]}}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	list, err := sv.DocumentHtmlDefinitions(ctx, "env", "1")
+	if err != nil {
+		t.Fatalf("DocumentHtmlDefinitions: %v", err)
+	}
+	if path != "/restapi/v2/accounts/1/envelopes/env/documents/1/html_definitions" {
+		t.Errorf("unexpected path %s", path)
+	}
+	expected := &DocumentHtmlDefinition{Source: "document", DisplayAnchors: []DocumentHtmlDisplayAnchor{{StartAnchor: "s", EndAnchor: "e"}}}
+	if len(list.HtmlDefinitions) != 1 || list.HtmlDefinitions[0].DocumentIdGuid != "g1" || !reflect.DeepEqual(list.HtmlDefinitions[0].HtmlDefinition, expected) {
+		t.Errorf("unexpected result %#v", list)
+	}
+	if _, err = sv.EnvelopeHtmlDefinitions(ctx, "env"); err != nil {
+		t.Fatalf("EnvelopeHtmlDefinitions: %v", err)
+	}
+	if path != "/restapi/v2/accounts/1/envelopes/env/html_definitions" {
+		t.Errorf("unexpected envelope path %s", path)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var hdr http.Header
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		hdr = req.Header
+		return testResponse(req, http.StatusOK, `{"envelopeId":"env"}`), nil
+	})
+	ctx = WithHeaders(ctx, http.Header{"X-Request-Id": {"first"}, "X-Tenant": {"acme"}})
+	ctx = WithHeaders(ctx, http.Header{
+		"x-request-id":  {"abc123"},
+		"Authorization": {"bearer stolen"},
+		"Content-Type":  {"text/plain"},
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	if _, err := sv.OnBehalfOf(SoboEmail("user@example.com")).EnvelopeUpdate(ctx, "env", &Envelope{Status: StatusSent}); err != nil {
+		t.Fatalf("EnvelopeUpdate: %v", err)
+	}
+	if got := hdr.Get("X-Request-Id"); got != "abc123" {
+		t.Errorf("expected X-Request-Id abc123; got %q", got)
+	}
+	if got := hdr.Get("X-Tenant"); got != "acme" {
+		t.Errorf("expected X-Tenant acme; got %q", got)
+	}
+	if got := hdr.Get("Authorization"); got != "bearer x" {
+		t.Errorf("Authorization must not be overridden; got %q", got)
+	}
+	if got := hdr.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type must not be overridden; got %q", got)
+	}
+	if got := hdr.Get("X-DocuSign-Act-As-User"); got != "user@example.com" {
+		t.Errorf("unexpected act as user %q", got)
+	}
+}
+
+func TestEnvelopeGet(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if inc := req.URL.Query().Get("include"); inc != "recipients,documents,custom_fields,tabs" {
+			return nil, fmt.Errorf("unexpected include %q", inc)
+		}
+		return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"completed",
+			"completedDateTime":"2017-03-01T10:00:00.0000000Z",
+			"recipients":{"signers":[{"recipientId":"1","tabs":{"textTabs":[{"tabLabel":"Name","value":"Signer"}]}}]},
+			"documents":[{"documentId":"1","name":"contract.pdf"}],
+			"customFields":{"textCustomFields":[{"name":"PID","value":"123"}]}}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	env, err := sv.EnvelopeGet(ctx, "env", EnvelopeIncludeRecipients, EnvelopeIncludeDocuments,
+		EnvelopeIncludeCustomFields, EnvelopeIncludeTabs)
+	if err != nil {
+		t.Fatalf("EnvelopeGet: %v", err)
+	}
+	if env.EnvelopeId != "env" || env.CompletedDateTime.Time().Year() != 2017 || len(env.Documents) != 1 {
+		t.Errorf("unexpected envelope %#v", env)
+	}
+	if v, _ := env.Recipients.Values().Get("Name"); v != "Signer" {
+		t.Errorf("expected tab value Signer; got %q", v)
+	}
+	if v, _ := env.CustomFields.Text("PID"); v != "123" {
+		t.Errorf("expected custom field 123; got %q", v)
+	}
+}
+
+// testWriteSeeker is an in memory io.WriteSeeker.
+type testWriteSeeker struct {
+	buf []byte
+	off int
+}
+
+func (w *testWriteSeeker) Write(p []byte) (int, error) {
+	if need := w.off + len(p); need > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, need-len(w.buf))...)
+	}
+	copy(w.buf[w.off:], p)
+	w.off += len(p)
+	return len(p), nil
+}
+
+func (w *testWriteSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		w.off = int(offset)
+	case io.SeekCurrent:
+		w.off += int(offset)
+	default:
+		return 0, errors.New("unsupported whence")
+	}
+	return int64(w.off), nil
+}
+
+// failingReader returns err after reading r.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		err = f.err
+	}
+	return n, err
+}
+
+func TestEnvelopeDocumentsCombinedResume(t *testing.T) {
+	defer func(b time.Duration) { resumeBackoff = b }(resumeBackoff)
+	resumeBackoff = time.Millisecond
+
+	pdf := make([]byte, 1000)
+	for i := range pdf {
+		pdf[i] = byte(i)
+	}
+	var ranges []string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		rng := req.Header.Get("Range")
+		ranges = append(ranges, rng)
+		switch len(ranges) {
+		case 1:
+			res := testResponse(req, http.StatusOK, "")
+			res.Body = ioutil.NopCloser(failingReader{bytes.NewReader(pdf[:300]), io.ErrUnexpectedEOF})
+			return res, nil
+		case 2:
+			return testResponse(req, http.StatusServiceUnavailable, `{"errorCode":"SERVICE_UNAVAILABLE"}`), nil
+		}
+		var start int
+		if _, err := fmt.Sscanf(rng, "bytes=%d-", &start); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusPartialContent, string(pdf[start:])), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	w := &testWriteSeeker{}
+	if err := sv.EnvelopeDocumentsCombinedResume(ctx, "env", w); err != nil {
+		t.Fatalf("EnvelopeDocumentsCombinedResume: %v", err)
+	}
+	if want := []string{"", "bytes=300-", "bytes=300-"}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("expected ranges %q; got %q", want, ranges)
+	}
+	if !bytes.Equal(w.buf, pdf) {
+		t.Errorf("downloaded content does not match")
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := sv.EnvelopeDocumentsCombinedResume(cctx, "env", &testWriteSeeker{}); err != context.Canceled {
+		t.Errorf("expected context.Canceled; got %v", err)
+	}
+}
+
+func TestRecipientCloneTabs(t *testing.T) {
+	var added Tabs
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /restapi/v2/accounts/1/envelopes/env/recipients/1/tabs":
+			return testResponse(req, http.StatusOK, `{
+				"signHereTabs":[{"tabId":"s1","recipientId":"1","documentId":"1","pageNumber":"2","xPosition":"100","yPosition":"200"}],
+				"textTabs":[{"tabId":"t1","recipientId":"1","tabLabel":"Address","anchorString":"/addr/"}],
+				"radioGroupTabs":[{"groupName":"Choice","recipientId":"1","radios":[{"tabId":"r1","value":"A"},{"tabId":"r2","value":"B"}]}],
+				"envelopeIdTabs":[{"tabId":"e1","pageNumber":"1"}]}`), nil
+		case "POST /restapi/v2/accounts/1/envelopes/env/recipients/3/tabs":
+			if err := json.NewDecoder(req.Body).Decode(&added); err != nil {
+				return nil, err
+			}
+			b, _ := json.Marshal(added)
+			return testResponse(req, http.StatusCreated, string(b)), nil
+		}
+		return nil, fmt.Errorf("unexpected call %s %s", req.Method, req.URL.Path)
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	if _, err := sv.RecipientCloneTabs(ctx, "env", "1", "3"); err != nil {
+		t.Fatalf("RecipientCloneTabs: %v", err)
+	}
+	if len(added.EnvelopeIdTabs) != 0 {
+		t.Errorf("envelope id tabs should not be cloned")
+	}
+	if len(added.SignHereTabs) != 1 || added.SignHereTabs[0].TabId != "" || added.SignHereTabs[0].RecipientID != "3" ||
+		added.SignHereTabs[0].PageNumber != "2" || added.SignHereTabs[0].XPosition != "100" {
+		t.Errorf("unexpected sign here tabs %#v", added.SignHereTabs)
+	}
+	if len(added.TextTabs) != 1 || added.TextTabs[0].TabLabel != "Address" || added.TextTabs[0].RecipientID != "3" {
+		t.Errorf("unexpected text tabs %#v", added.TextTabs)
+	}
+	if len(added.RadioGroupTabs) != 1 || added.RadioGroupTabs[0].RecipientID != "3" ||
+		len(added.RadioGroupTabs[0].Radios) != 2 || added.RadioGroupTabs[0].Radios[0].TabId != "" {
+		t.Errorf("unexpected radio group tabs %#v", added.RadioGroupTabs)
+	}
+}
+
+func TestBrandAssets(t *testing.T) {
+	var method, path, query, ct string
+	var body []byte
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		method, path, query, ct = req.Method, req.URL.Path, req.URL.RawQuery, req.Header.Get("Content-Type")
+		body = nil
+		if req.Body != nil {
+			var err error
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+		}
+		if strings.HasSuffix(path, "/resources/email") && method == "GET" {
+			return testResponse(req, http.StatusOK, `<root/>`), nil
+		}
+		return testResponse(req, http.StatusOK, `{"resourcesContentType":"email","modifiedBy":"me"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if err := sv.BrandLogoPut(ctx, "b1", "signature", strings.NewReader("x")); err == nil {
+		t.Errorf("expected error for invalid logo type")
+	}
+	if path != "" {
+		t.Errorf("invalid logo type: unexpected request %s", path)
+	}
+	png := "\x89PNG\r\n\x1a\nlogo"
+	if err := sv.BrandLogoPut(ctx, "b1", BrandLogoPrimary, strings.NewReader(png)); err != nil {
+		t.Fatalf("BrandLogoPut: %v", err)
+	}
+	if method != "PUT" || path != "/restapi/v2/accounts/1/brands/b1/logos/primary" || ct != "image/png" || string(body) != png {
+		t.Errorf("BrandLogoPut: unexpected request %s %s %s %q", method, path, ct, body)
+	}
+
+	res, err := sv.BrandResourcesGet(ctx, "b1", BrandResourceEmail, BrandResourceLang("fr"), BrandResourceMaster)
+	if err != nil {
+		t.Fatalf("BrandResourcesGet: %v", err)
+	}
+	res.Body.Close()
+	if path != "/restapi/v2/accounts/1/brands/b1/resources/email" || query != "langcode=fr&return_master=true" {
+		t.Errorf("BrandResourcesGet: unexpected request %s?%s", path, query)
+	}
+
+	r, err := sv.BrandResourcesPut(ctx, "b1", BrandResourceEmail, strings.NewReader("<root/>"))
+	if err != nil {
+		t.Fatalf("BrandResourcesPut: %v", err)
+	}
+	if r.ResourcesContentType != "email" || r.ModifiedBy != "me" {
+		t.Errorf("BrandResourcesPut: unexpected result %#v", r)
+	}
+	if method != "PUT" || !strings.HasPrefix(ct, "multipart/form-data") || !strings.Contains(string(body), "filename=\"file.xml\"") || !strings.Contains(string(body), "<root/>") {
+		t.Errorf("BrandResourcesPut: unexpected request %s %s %s", method, ct, body)
+	}
+}
+
+func TestTransferRuleForUser(t *testing.T) {
+	var method, path string
+	var rule map[string]interface{}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		method, path, rule = req.Method, req.URL.Path, nil
+		if req.Body != nil {
+			if err := json.NewDecoder(req.Body).Decode(&rule); err != nil {
+				return nil, err
+			}
+		}
+		return testResponse(req, http.StatusCreated, `{"envelopeTransferRules":[{"envelopeTransferRuleId":"r1","enabled":"true"}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if _, err := sv.TransferRuleForUser(ctx, "u1", ""); err == nil {
+		t.Errorf("expected error for missing to user")
+	}
+	if path != "" {
+		t.Errorf("missing user: unexpected request %s", path)
+	}
+	list, err := sv.TransferRuleForUser(ctx, "u1", "u2")
+	if err != nil {
+		t.Fatalf("TransferRuleForUser: %v", err)
+	}
+	if method != "POST" || path != "/restapi/v2/accounts/1/envelopes/transfer_rules" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+	b, _ := json.Marshal(rule)
+	if string(b) != `{"enabled":true,"fromUsers":[{"userId":"u1"}],"toUser":{"userId":"u2"}}` {
+		t.Errorf("unexpected payload %s", b)
+	}
+	if len(list.EnvelopeTransferRules) != 1 || list.EnvelopeTransferRules[0].EnvelopeTransferRuleId != "r1" ||
+		list.EnvelopeTransferRules[0].Enabled == nil || !bool(*list.EnvelopeTransferRules[0].Enabled) {
+		t.Errorf("unexpected result %#v", list)
+	}
+	disabled := DSBool(false)
+	if _, err = sv.EnvelopeTransferRuleUpdate(ctx, "r1", &EnvelopeTransferRule{Enabled: &disabled}); err != nil {
+		t.Fatalf("EnvelopeTransferRuleUpdate: %v", err)
+	}
+	if b, _ = json.Marshal(rule); method != "PUT" || string(b) != `{"enabled":false}` {
+		t.Errorf("update: unexpected request %s %s", method, b)
+	}
+	if err = sv.EnvelopeTransferRuleDelete(ctx, "r1"); err != nil {
+		t.Fatalf("EnvelopeTransferRuleDelete: %v", err)
+	}
+	if method != "DELETE" || path != "/restapi/v2/accounts/1/envelopes/transfer_rules/r1" {
+		t.Errorf("delete: unexpected request %s %s", method, path)
+	}
+}
+
+func TestEnvelopeWorkflow(t *testing.T) {
+	var method, path string
+	var payload []byte
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		method, path, payload = req.Method, req.URL.Path, nil
+		if req.Body != nil {
+			var err error
+			if payload, err = ioutil.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+		}
+		return testResponse(req, http.StatusOK, `{"workflowStatus":"paused","workflowSteps":[{"workflowStepId":"s1",`+
+			`"action":"pause_before","itemId":"2","triggerOnItem":"routing_order","delayedRouting":{"rules":[{"delay":"1.02:00:00"}]}}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if r := ResumeAfter(26*time.Hour + 3*time.Minute + 4*time.Second); r.Delay != "1.02:03:04" {
+		t.Errorf("ResumeAfter: expected 1.02:03:04; got %s", r.Delay)
+	}
+	at := time.Date(2017, 5, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	wf := &EnvelopeWorkflow{ScheduledSending: &ScheduledSending{Rules: []EnvelopeDelayRule{ResumeAt(at)}}}
+	if _, err := sv.EnvelopeWorkflowUpdate(ctx, "env", wf); err != nil {
+		t.Fatalf("EnvelopeWorkflowUpdate: %v", err)
+	}
+	if method != "PUT" || path != "/restapi/v2/accounts/1/envelopes/env/workflow" ||
+		string(payload) != `{"scheduledSending":{"rules":[{"resumeDate":"2017-05-01T09:30:00-05:00"}]}}` {
+		t.Errorf("update: unexpected request %s %s %s", method, path, payload)
+	}
+
+	ret, err := sv.EnvelopeWorkflowGet(ctx, "env")
+	if err != nil {
+		t.Fatalf("EnvelopeWorkflowGet: %v", err)
+	}
+	if method != "GET" || path != "/restapi/v2/accounts/1/envelopes/env/workflow" {
+		t.Errorf("get: unexpected request %s %s", method, path)
+	}
+	if ret.WorkflowStatus != "paused" || len(ret.WorkflowSteps) != 1 || ret.WorkflowSteps[0].DelayedRouting == nil ||
+		ret.WorkflowSteps[0].DelayedRouting.Rules[0].Delay != "1.02:00:00" {
+		t.Errorf("unexpected workflow %#v", ret)
+	}
+
+	b, _ := json.Marshal(&Envelope{Workflow: &EnvelopeWorkflow{WorkflowStatus: "in_progress"}})
+	if !strings.Contains(string(b), `"workflow":{"workflowStatus":"in_progress"}`) {
+		t.Errorf("unexpected envelope json %s", b)
+	}
+}
+
+func TestAllRecipients(t *testing.T) {
+	var rl RecipientList
+	err := json.Unmarshal([]byte(`{
+		"signers":[
+			{"recipientId":"1","name":"S1","email":"s1@example.com","routingOrder":"1","status":"completed","signedDateTime":"2017-05-01T10:00:00Z"},
+			{"recipientId":"4","name":"S2","email":"s2@example.com","routingOrder":"3","status":"created"}],
+		"carbonCopies":[{"recipientId":"2","name":"CC","email":"cc@example.com","routingOrder":"2","status":"created"}],
+		"inPersonSigners":[{"recipientId":"3","name":"IP","hostEmail":"host@example.com","routingOrder":"2","status":"sent"}],
+		"seals":[{"recipientId":"5","name":"Seal","routingOrder":"4","status":"completed","completedDateTime":"2017-05-02T10:00:00Z"}],
+		"agents":[{"recipientId":"6","name":"A","email":"a@example.com","status":"created"}]
+	}`), &rl)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	expected := []RecipientSummary{
+		{RecipientType: "agent", RecipientId: "6", Name: "A", Email: "a@example.com", Status: "created"},
+		{RecipientType: "signer", RecipientId: "1", Name: "S1", Email: "s1@example.com", RoutingOrder: "1", Status: "completed", SignedDateTime: "2017-05-01T10:00:00Z"},
+		{RecipientType: "carbonCopy", RecipientId: "2", Name: "CC", Email: "cc@example.com", RoutingOrder: "2", Status: "created"},
+		{RecipientType: "inPersonSigner", RecipientId: "3", Name: "IP", Email: "host@example.com", RoutingOrder: "2", Status: "sent"},
+		{RecipientType: "signer", RecipientId: "4", Name: "S2", Email: "s2@example.com", RoutingOrder: "3", Status: "created"},
+		{RecipientType: "seal", RecipientId: "5", Name: "Seal", RoutingOrder: "4", Status: "completed", SignedDateTime: "2017-05-02T10:00:00Z"},
+	}
+	if all := rl.AllRecipients(); !reflect.DeepEqual(all, expected) {
+		t.Errorf("expected %#v; got %#v", expected, all)
+	}
+	if all := (RecipientList{}).AllRecipients(); len(all) != 0 {
+		t.Errorf("empty list: got %#v", all)
+	}
+}
+
+func TestEnvelopeWorkflowResume(t *testing.T) {
+	var status, method, path, query, payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "GET" {
+			return testResponse(req, http.StatusOK, `{"workflowStatus":"`+status+`"}`), nil
+		}
+		b, err := ioutil.ReadAll(req.Body)
+		method, path, query, payload = req.Method, req.URL.Path, req.URL.RawQuery, string(b)
+		return testResponse(req, http.StatusOK, `{}`), err
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	status = "in_progress"
+	if err := sv.EnvelopeWorkflowResume(ctx, "env"); err == nil || !strings.Contains(err.Error(), "not paused") {
+		t.Errorf("expected not paused error; got %v", err)
+	}
+	if method != "" {
+		t.Errorf("not paused: unexpected update %s %s", method, path)
+	}
+	status = "paused"
+	if err := sv.EnvelopeWorkflowResume(ctx, "env"); err != nil {
+		t.Fatalf("EnvelopeWorkflowResume: %v", err)
+	}
+	if method != "PUT" || path != "/restapi/v2/accounts/1/envelopes/env" || query != "resend_envelope=true" ||
+		payload != `{"workflow":{"workflowStatus":"in_progress"}}` {
+		t.Errorf("unexpected request %s %s?%s %s", method, path, query, payload)
+	}
+}
+
+func TestCertificateOfCompletion(t *testing.T) {
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/envelopes/env"):
+			return testResponse(req, http.StatusOK, `{"envelopeId":"env","emailSubject":"Contract","status":"completed",`+
+				`"sentDateTime":"2017-05-01T09:00:00Z","completedDateTime":"2017-05-01T11:00:00Z"}`), nil
+		case strings.HasSuffix(req.URL.Path, "/envelopes/env/recipients"):
+			return testResponse(req, http.StatusOK, `{"signers":[{"recipientId":"1","name":"Signer","email":"s@example.com",`+
+				`"routingOrder":"1","status":"completed","signedDateTime":"2017-05-01T10:00:00Z","accessCode":"[Required]",`+
+				`"idCheckConfigurationName":"SMS Auth $"}],"carbonCopies":[{"recipientId":"2","name":"Copy","email":"c@example.com",`+
+				`"routingOrder":"2","status":"completed"}]}`), nil
+		case strings.HasSuffix(req.URL.Path, "/envelopes/env/audit_events"):
+			return testResponse(req, http.StatusOK, `{"auditEvents":[
+				{"eventFields":[{"name":"Action","value":"Registered"},{"name":"UserName","value":"Sender"},{"name":"ClientIPAddress","value":"10.0.0.1"}]},
+				{"eventFields":[{"name":"Action","value":"Viewed"},{"name":"UserName","value":"Signer"},{"name":"ClientIPAddress","value":"10.0.0.2"}]},
+				{"eventFields":[{"name":"Action","value":"Signed"},{"name":"UserName","value":"Signer"},{"name":"ClientIPAddress","value":"10.0.0.3"}]},
+				{"eventFields":[{"name":"Action","value":"Printed"},{"name":"UserName","value":"Signer"},{"name":"ClientIPAddress","value":"10.0.0.4"}]}]}`), nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	cert, err := sv.CertificateOfCompletion(ctx, "env")
+	if err != nil {
+		t.Fatalf("CertificateOfCompletion: %v", err)
+	}
+	if cert.EnvelopeId != "env" || cert.EmailSubject != "Contract" || cert.Status != "completed" ||
+		cert.CompletedDateTime != "2017-05-01T11:00:00Z" || len(cert.Events) != 4 || len(cert.Recipients) != 2 {
+		t.Fatalf("unexpected certificate %#v", cert)
+	}
+	r := cert.Recipients[0]
+	if r.RecipientType != "signer" || r.SignedDateTime != "2017-05-01T10:00:00Z" || r.ClientIPAddress != "10.0.0.3" ||
+		len(r.Events) != 3 || !reflect.DeepEqual(r.AuthenticationMethods, []string{"AccessCode", "SMS Auth $"}) {
+		t.Errorf("unexpected signer %#v", r)
+	}
+	r = cert.Recipients[1]
+	if r.RecipientType != "carbonCopy" || r.ClientIPAddress != "" || len(r.Events) != 0 || r.AuthenticationMethods != nil {
+		t.Errorf("unexpected carbon copy %#v", r)
+	}
+}
+
+func TestAccountSettings(t *testing.T) {
+	var method, path, payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		method, path, payload = req.Method, req.URL.Path, ""
+		if req.Body != nil {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			payload = string(b)
+		}
+		return testResponse(req, http.StatusOK, `{"accountSettings":[{"name":"allowEnvelopeCorrect","value":"true"},`+
+			`{"name":"dataFieldRegExMetadata","value":"[0-9]+"}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	settings, err := sv.AccountSettings(ctx)
+	if err != nil {
+		t.Fatalf("AccountSettings: %v", err)
+	}
+	if method != "GET" || path != "/restapi/v2/accounts/1/settings" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+	if v, ok := settings.AccountSettings.Get("allowEnvelopeCorrect"); !ok || v != "true" {
+		t.Errorf("expected allowEnvelopeCorrect true; got %q %v", v, ok)
+	}
+	if err = sv.AccountSettingsUpdate(ctx, []NmVal{{Name: "allowEnvelopeCorrect", Value: "false"}}); err != nil {
+		t.Fatalf("AccountSettingsUpdate: %v", err)
+	}
+	if method != "PUT" || path != "/restapi/v2/accounts/1/settings" ||
+		payload != `{"accountSettings":[{"name":"allowEnvelopeCorrect","value":"false"}]}` {
+		t.Errorf("update: unexpected request %s %s %s", method, path, payload)
+	}
+}
+
+func TestRecipientAuthenticationStatus(t *testing.T) {
+	var query string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		query = req.URL.RawQuery
+		return testResponse(req, http.StatusOK, `{"signers":[
+			{"recipientId":"1","recipientAuthenticationStatus":{"accessCodeResult":{"status":"Passed","eventTimestamp":"2017-05-01T10:00:00Z"},
+				"smsAuthResult":{"status":"Failed","failureDescription":"wrong code"}}},
+			{"recipientId":"2","recipientAuthenticationStatus":{"phoneAuthResult":{"status":"Passed"}}},
+			{"recipientId":"3"}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	rl, err := sv.Recipients(ctx, "env", RecipientsIncludeExtended)
+	if err != nil {
+		t.Fatalf("Recipients: %v", err)
+	}
+	if query != "include_extended=true" {
+		t.Errorf("unexpected query %s", query)
+	}
+	if len(rl.Signers) != 3 {
+		t.Fatalf("expected 3 signers; got %d", len(rl.Signers))
+	}
+	st := rl.Signers[0].RecipientAuthenticationStatus
+	if st == nil || st.AccessCodeResult.Status != "Passed" || st.SmsAuthResult.FailureDescription != "wrong code" || !st.Failed() {
+		t.Errorf("signer 1: unexpected status %#v", st)
+	}
+	if st = rl.Signers[1].RecipientAuthenticationStatus; st == nil || st.Failed() {
+		t.Errorf("signer 2: unexpected status %#v", st)
+	}
+	if rl.Signers[2].RecipientAuthenticationStatus != nil {
+		t.Errorf("signer 3: expected nil status")
+	}
+}
+
+// countLogger counts logged requests and responses.
+type countLogger struct {
+	requests, responses int
+}
+
+func (l *countLogger) LogRequest(ctx context.Context, payload interface{}, req *http.Request) {
+	l.requests++
+}
+
+func (l *countLogger) LogResponse(ctx context.Context, res *http.Response) io.Reader {
+	l.responses++
+	return res.Body
+}
+
+func TestServiceOptions(t *testing.T) {
+	var svcCalls, ctxCalls int
+	svcClient := &http.Client{Transport: testTransport(func(req *http.Request) (*http.Response, error) {
+		svcCalls++
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		ctxCalls++
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	ctxLog, svcLog := &countLogger{}, &countLogger{}
+	ctx = context.WithValue(ctx, CallLogger, ctxLog)
+	cred := &OauthCredential{AccessToken: "x", AccountId: "1"}
+
+	if _, err := New(cred, "").AccountCustomFields(ctx); err != nil {
+		t.Fatalf("context options: %v", err)
+	}
+	if ctxCalls != 1 || svcCalls != 0 || ctxLog.requests != 1 || ctxLog.responses != 1 {
+		t.Errorf("context options: unexpected counts %d %d %#v", ctxCalls, svcCalls, ctxLog)
+	}
+	sv := New(cred, "", WithHTTPClient(svcClient), WithLogger(svcLog))
+	if _, err := sv.AccountCustomFields(ctx); err != nil {
+		t.Fatalf("service options: %v", err)
+	}
+	if ctxCalls != 1 || svcCalls != 1 || ctxLog.requests != 1 || svcLog.requests != 1 || svcLog.responses != 1 {
+		t.Errorf("service options: unexpected counts %d %d %#v %#v", ctxCalls, svcCalls, ctxLog, svcLog)
+	}
+	if _, err := sv.OnBehalfOf(SoboEmail("a@example.com")).AccountCustomFields(ctx); err != nil || svcCalls != 2 {
+		t.Errorf("OnBehalfOf copy: expected service client; got %d calls, %v", svcCalls, err)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(200 * time.Millisecond):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+	defer close(release)
+	defer func(d time.Duration) { DefaultTimeout = d }(DefaultTimeout)
+	DefaultTimeout = 20 * time.Millisecond
+
+	sv := New(StaticCredential{BaseURL: srv.URL, AccountId: "1"}, "")
+	start := time.Now()
+	if _, err := sv.AccountCustomFields(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded; got %v", err)
+	}
+	if d := time.Since(start); d > 150*time.Millisecond {
+		t.Errorf("timeout took %v", d)
+	}
+
+	// a context deadline is not shortened
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := sv.AccountCustomFields(ctx); err != nil {
+		t.Errorf("context deadline: %v", err)
+	}
+
+	// a client set on the context is not limited
+	if _, err := sv.AccountCustomFields(context.WithValue(context.Background(), HTTPClient, &http.Client{})); err != nil {
+		t.Errorf("context client: %v", err)
+	}
+}
+
+func TestDefaultTimeoutToken(t *testing.T) {
+	defer func(rt http.RoundTripper) { defaultClient.Transport = rt }(defaultClient.Transport)
+	defaultClient.Transport = testTransport(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	defer func(d time.Duration) { DefaultTimeout = d }(DefaultTimeout)
+	DefaultTimeout = 20 * time.Millisecond
+
+	cfg := &Config{IntegratorKey: "key", UserName: "user", Password: "pwd", AccountId: "1"}
+	if _, err := cfg.OauthCredential(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("OauthCredential: expected context.DeadlineExceeded; got %v", err)
+	}
+	if _, err := cfg.OauthCredentialOnBehalfOf(context.Background(), OauthCredential{AccessToken: "x"}, "other"); err != context.DeadlineExceeded {
+		t.Errorf("OauthCredentialOnBehalfOf: expected context.DeadlineExceeded; got %v", err)
+	}
+	if err := (OauthCredential{AccessToken: "x", AccountId: "1"}).Revoke(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("Revoke: expected context.DeadlineExceeded; got %v", err)
+	}
+}
+
+func TestEnvelopePrefill(t *testing.T) {
+	var puts int
+	var payload RecipientList
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "GET" {
+			return testResponse(req, http.StatusOK, `{"signers":[{"recipientId":"1"},{"recipientId":"2"}],`+
+				`"inPersonSigners":[{"recipientId":"3"}],"carbonCopies":[{"recipientId":"4"}]}`), nil
+		}
+		puts++
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusOK, `{"recipientUpdateResults":[{"recipientId":"1"},`+
+			`{"recipientId":"3","tabs":{"textTabs":[{"tabId":"t3","errorDetails":{"errorCode":"INVALID_TAB","message":"bad"}}]}}]}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	text := func(id, value string) *Tabs {
+		return &Tabs{TextTabs: []TextTab{{BasePosTab: BasePosTab{TabId: id}, Value: value}}}
+	}
+
+	if _, err := sv.EnvelopePrefill(ctx, "env", map[string]*Tabs{"1": text("t1", "a"), "4": text("t4", "b")}); err == nil {
+		t.Errorf("expected error for carbon copy recipient")
+	}
+	if puts != 0 {
+		t.Errorf("invalid recipient: expected no update")
+	}
+	rl, err := sv.EnvelopePrefill(ctx, "env", map[string]*Tabs{"1": text("t1", "a"), "3": text("t3", "c")})
+	if err != nil {
+		t.Fatalf("EnvelopePrefill: %v", err)
+	}
+	if puts != 1 || len(payload.Signers) != 1 || len(payload.InPersonSigners) != 1 || len(payload.CarbonCopies) != 0 ||
+		payload.Signers[0].RecipientId != "1" || payload.Signers[0].Tabs.TextTabs[0].Value != "a" ||
+		payload.InPersonSigners[0].RecipientId != "3" || payload.InPersonSigners[0].Tabs.TextTabs[0].TabId != "t3" {
+		t.Errorf("unexpected payload %#v", payload)
+	}
+	if rl.Signers[0].Tabs.FirstError() != nil || rl.Signers[0].Tabs.TextTabs[0].Value != "a" {
+		t.Errorf("signer 1: unexpected result %#v", rl.Signers[0])
+	}
+	if e := rl.InPersonSigners[0].Tabs.FirstError(); e == nil || !strings.Contains(e.Error(), "INVALID_TAB") {
+		t.Errorf("in person signer 3: expected tab error; got %v", e)
+	}
+}
+
+func TestEnvelopeCreateWithParams(t *testing.T) {
+	var query, ct string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		query, ct = req.URL.RawQuery, req.Header.Get("Content-Type")
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusCreated, `{"envelopeId":"env","status":"created"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	env := &Envelope{Status: "created", CompositeTemplates: []CompositeTemplate{{}}}
+
+	if _, err := sv.EnvelopeCreate(ctx, env); err != nil || query != "" {
+		t.Errorf("EnvelopeCreate: unexpected query %q, %v", query, err)
+	}
+	res, err := sv.EnvelopeCreateWithParams(ctx, env, []EnvelopeCreateParam{EnvelopeCreateMergeRolesOnDraft, EnvelopeCreateChangeRoutingOrder})
+	if err != nil {
+		t.Fatalf("EnvelopeCreateWithParams: %v", err)
+	}
+	if query != "change_routing_order=true&merge_roles_on_draft=true" || ct != "application/json" || res.EnvelopeId != "env" {
+		t.Errorf("unexpected request %q %s %#v", query, ct, res)
+	}
+	f := &UploadFile{ContentType: "application/pdf", FileName: "a.pdf", Id: "1", Data: strings.NewReader("%PDF")}
+	if _, err = sv.EnvelopeCreateWithParams(ctx, env, []EnvelopeCreateParam{EnvelopeCreateMergeRolesOnDraft}, f); err != nil {
+		t.Fatalf("EnvelopeCreateWithParams files: %v", err)
+	}
+	if query != "merge_roles_on_draft=true" || !strings.HasPrefix(ct, "multipart/form-data") {
+		t.Errorf("files: unexpected request %q %s", query, ct)
+	}
+}
+
+func TestEnvelopeDelete(t *testing.T) {
+	var moved string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(req.URL.Path, "/envelopes/status") {
+			return testResponse(req, http.StatusOK, `{"envelopes":[{"envelopeId":"d1","status":"created"},`+
+				`{"envelopeId":"d2","status":"created"},{"envelopeId":"s1","status":"sent"}]}`), nil
+		}
+		moved = req.Method + " " + req.URL.Path + " " + string(b)
+		return testResponse(req, http.StatusOK, `{}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	if err := sv.EnvelopeDelete(ctx, "d1", "s1"); err == nil || !strings.Contains(err.Error(), "s1 is not a draft") {
+		t.Errorf("expected not a draft error; got %v", err)
+	}
+	if err := sv.EnvelopeDelete(ctx, "d1", "x1"); err == nil || !strings.Contains(err.Error(), "x1 not found") {
+		t.Errorf("expected not found error; got %v", err)
+	}
+	if moved != "" {
+		t.Errorf("unexpected move %s", moved)
+	}
+	if err := sv.EnvelopeDelete(ctx, "d1", "d2"); err != nil {
+		t.Fatalf("EnvelopeDelete: %v", err)
+	}
+	if moved != `PUT /restapi/v2/accounts/1/folders/recyclebin {"envelopeIds":["d1","d2"]}` {
+		t.Errorf("unexpected move %s", moved)
+	}
+}
+
+func TestCompositeTemplateJSON(t *testing.T) {
+	b, err := json.Marshal(CompositeTemplate{ServerTemplates: []ServerTemplate{{Sequence: "1", TemplateId: "tmpl"}}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(b) != `{"serverTemplates":[{"sequence":"1","templateId":"tmpl"}]}` {
+		t.Errorf("unset document marshaled: %s", b)
+	}
+
+	// composite envelope in the form of the api documentation example
+	payload := `{
+		"status":"sent",
+		"emailSubject":"Please sign",
+		"compositeTemplates":[{
+			"compositeTemplateId":"1",
+			"serverTemplates":[{"sequence":"1","templateId":"55A80182-2E9F-435D-9B16-FD1E1C0F9D74"}],
+			"inlineTemplates":[{
+				"sequence":"2",
+				"recipients":{"signers":[{"email":"s@example.com","name":"Signer","recipientId":"1","roleName":"Signer","routingOrder":"1"}]}
+			}],
+			"document":{"documentId":"1","name":"contract.pdf","fileExtension":"pdf","documentBase64":"JVBERi0xLjQ="}
+		},{
+			"compositeTemplateId":"2",
+			"inlineTemplates":[{
+				"sequence":"1",
+				"documents":[{"documentId":"2","name":"addendum.pdf","fileExtension":"pdf","documentBase64":"JVBERi0xLjQ="}]
+			}]
+		}]
+	}`
+	var env Envelope
+	if err = json.Unmarshal([]byte(payload), &env); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(env.CompositeTemplates) != 2 || env.CompositeTemplates[0].Document == nil ||
+		env.CompositeTemplates[0].Document.Name != "contract.pdf" || string(env.CompositeTemplates[0].Document.DocumentBase64) != "%PDF-1.4" ||
+		env.CompositeTemplates[1].Document != nil || len(env.CompositeTemplates[1].InlineTemplates[0].Documents) != 1 {
+		t.Fatalf("unexpected composite templates %#v", env.CompositeTemplates)
+	}
+	if b, err = json.Marshal(env); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var expected, got interface{}
+	json.Unmarshal([]byte(payload), &expected)
+	json.Unmarshal(b, &got)
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("round trip: expected %s; got %s", payload, b)
+	}
+}
+
+func TestConsoleView(t *testing.T) {
+	var path, payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		path, payload = req.URL.Path, string(b)
+		return testResponse(req, http.StatusCreated, `{"url":"https://demo.docusign.net/Member/StartInSession.aspx?t=abc"}`), err
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	u, err := sv.ConsoleView(ctx, "env", "https://example.com/done")
+	if err != nil {
+		t.Fatalf("ConsoleView: %v", err)
+	}
+	if path != "/restapi/v2/accounts/1/views/console" || payload != `{"envelopeId":"env","returnUrl":"https://example.com/done"}` {
+		t.Errorf("unexpected request %s %s", path, payload)
+	}
+	if u.Url != "https://demo.docusign.net/Member/StartInSession.aspx?t=abc" {
+		t.Errorf("unexpected url %s", u.Url)
+	}
+	if _, err = sv.ConsoleView(ctx, "", ""); err != nil {
+		t.Fatalf("ConsoleView landing: %v", err)
+	}
+	if payload != `{}` {
+		t.Errorf("landing: unexpected payload %s", payload)
+	}
+}
+
+func TestSignerSMSDelivery(t *testing.T) {
+	var payload string
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		payload = string(b)
+		return testResponse(req, http.StatusCreated, `{"envelopeId":"env","status":"sent"}`), err
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+	signer := Signer{DeliveryMethod: DeliveryMethodSMS}
+	signer.RecipientId, signer.Name = "1", "Field Worker"
+	env := &Envelope{Status: "sent", Recipients: &RecipientList{Signers: []Signer{signer}}}
+
+	if _, err := sv.EnvelopeCreate(ctx, env); err == nil || !strings.Contains(err.Error(), "no phone number") {
+		t.Errorf("expected phone number error; got %v", err)
+	}
+	if payload != "" {
+		t.Errorf("envelope sent without phone number: %s", payload)
+	}
+	inline := &Envelope{Status: "sent", CompositeTemplates: []CompositeTemplate{{
+		InlineTemplates: []InlineTemplate{{Sequence: "1", Recipients: &RecipientList{Signers: []Signer{signer}}}},
+	}}}
+	inline.CompositeTemplates[0].InlineTemplates[0].Recipients.Signers[0].DeliveryMethod = "whatsapp"
+	if _, err := sv.EnvelopeCreate(ctx, inline); err == nil {
+		t.Errorf("expected phone number error for inline template")
+	}
+
+	env.Recipients.Signers[0].PhoneNumber = &PhoneNumber{CountryCode: "1", Number: "4155550100"}
+	if _, err := sv.EnvelopeCreate(ctx, env); err != nil {
+		t.Fatalf("EnvelopeCreate: %v", err)
+	}
+	if !strings.Contains(payload, `"deliveryMethod":"SMS","phoneNumber":{"countryCode":"1","number":"4155550100"}`) {
+		t.Errorf("unexpected payload %s", payload)
+	}
+}
+
+func TestWatchEnvelope(t *testing.T) {
+	var polls int32
+	statuses := []string{"sent", "", "sent", "net", "delivered", "delivered", "completed"}
+	ctx := testContext(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&polls, 1) - 1
+		if int(n) >= len(statuses) {
+			return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"completed"}`), nil
+		}
+		switch statuses[n] {
+		case "":
+			return testResponse(req, http.StatusServiceUnavailable, `{"errorCode":"SERVICE_UNAVAILABLE","message":"try again"}`), nil
+		case "net":
+			return nil, &net.DNSError{Err: "no such host", Name: "www.docusign.net", IsTemporary: true}
+		}
+		return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"`+statuses[n]+`"}`), nil
+	})
+	sv := New(&OauthCredential{AccessToken: "x", AccountId: "1"}, "")
+
+	updates, errs := sv.WatchEnvelope(ctx, "env", time.Millisecond)
+	var got []string
+	for u := range updates {
+		got = append(got, u.Status)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("WatchEnvelope: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"sent", "delivered", "completed"}) {
+		t.Errorf("expected sent, delivered, completed; got %v", got)
+	}
+	if n := atomic.LoadInt32(&polls); n != 7 {
+		t.Errorf("expected 7 polls; got %d", n)
+	}
+
+	updates, errs = sv.WatchEnvelope(ctx, "env", 0)
+	if _, ok := <-updates; ok {
+		t.Errorf("expected closed updates for zero interval")
+	}
+	if err := <-errs; err == nil || !strings.Contains(err.Error(), "interval must be positive") {
+		t.Errorf("expected interval error; got %v", err)
+	}
+	ctx = testContext(func(req *http.Request) (*http.Response, error) {
+		return testResponse(req, http.StatusNotFound, `{"errorCode":"ENVELOPE_DOES_NOT_EXIST","message":"not found"}`), nil
+	})
+	updates, errs = sv.WatchEnvelope(ctx, "env", time.Millisecond)
+	for range updates {
+		t.Errorf("unexpected update for missing envelope")
+	}
+	if re, ok := (<-errs).(*ResponseError); !ok || re.Err != "ENVELOPE_DOES_NOT_EXIST" {
+		t.Errorf("expected ENVELOPE_DOES_NOT_EXIST; got %v", re)
+	}
+
+	cctx, cancel := context.WithCancel(testContext(func(req *http.Request) (*http.Response, error) {
+		return testResponse(req, http.StatusOK, `{"envelopeId":"env","status":"sent"}`), nil
+	}))
+	updates, errs = sv.WatchEnvelope(cctx, "env", time.Millisecond)
+	if u := <-updates; u.Status != "sent" {
+		t.Errorf("expected sent; got %s", u.Status)
+	}
+	cancel()
+	for range updates {
+		t.Errorf("unexpected update after cancel")
+	}
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("expected context.Canceled; got %v", err)
+	}
+}
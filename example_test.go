@@ -61,7 +61,7 @@ func ExampleOauthCredential() {
 }
 
 func ExampleOnBehalfOf(ctx context.Context, sv *docusign.Service, userEmail string) (string, error) {
-	info, err := sv.OnBehalfOf(userEmail).LoginInformation(ctx,
+	info, err := sv.OnBehalfOf(docusign.SoboEmail(userEmail)).LoginInformation(ctx,
 		docusign.LoginInformationSettingsAll,
 		docusign.LoginInformationIncludeApiPassword)
 	if err != nil {
@@ -173,7 +173,7 @@ func ExampleEnvelopeCreate(ctx context.Context, sv *docusign.Service, userID str
 		},
 	}
 
-	newEnvelope, err := sv.OnBehalfOf(userID).EnvelopeCreate(ctx, env, &uploadDoc)
+	newEnvelope, err := sv.OnBehalfOf(docusign.SoboUserId(userID)).EnvelopeCreate(ctx, env, &uploadDoc)
 	if err != nil {
 		log.Fatal(err)
 	}
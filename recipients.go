@@ -5,6 +5,15 @@
 
 package docusign
 
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // RecipientList defines the recipients for an envelope
 // RestApi Documentation
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Recipient%20Parameter.htm
@@ -15,13 +24,25 @@ type RecipientList struct {
 	Editors             []Editor            `json:"editors,omitempty"`
 	InPersonSigners     []InPersonSigner    `json:"inPersonSigners,omitempty"`
 	Intermediaries      []Intermediary      `json:"intermediaries,omitempty"`
+	Seals               []SealSign          `json:"seals,omitempty"`
 	Signers             []Signer            `json:"signers,omitempty"`
 	RecipientCount      string              `json:"recipientCount,omitempty"`
 }
 
+// eachTabs calls fn with the recipientId and tabs of each
+// recipient type that may have tabs.
+func (r RecipientList) eachTabs(fn func(recipId string, tabs *Tabs)) {
+	for _, x := range r.Signers {
+		fn(x.RecipientId, x.Tabs)
+	}
+	for _, x := range r.InPersonSigners {
+		fn(x.RecipientId, x.Tabs)
+	}
+}
+
 // Values returns a NmVal slice contiaing the
 // tabLabel and value for each tab in the RecipientList.
-func (r RecipientList) Values() []NmVal {
+func (r RecipientList) Values() NmVals {
 	v := make([]NmVal, 0)
 	for _, x := range r.InPersonSigners {
 		v = append(v, x.Tabs.Values()...)
@@ -32,6 +53,87 @@ func (r RecipientList) Values() []NmVal {
 	return v
 }
 
+// RecipientSummary is the common view of a recipient of any type
+// returned by RecipientList.AllRecipients.
+type RecipientSummary struct {
+	// RecipientType is the json name of the type, e.g. "signer" or "carbonCopy".
+	RecipientType  string
+	RecipientId    string
+	Name           string
+	Email          string
+	RoutingOrder   string
+	Status         string
+	SignedDateTime DSTime
+}
+
+// AllRecipients returns a summary of every recipient in the list ordered
+// by routing order.  Recipients with the same routing order keep the
+// order of the list's fields.  SignedDateTime is set for signers,
+// in person signers and seals (the seal's completed date).
+func (r RecipientList) AllRecipients() []RecipientSummary {
+	var v []RecipientSummary
+	add := func(typ string, rx Recipient, email string) {
+		v = append(v, RecipientSummary{
+			RecipientType: typ,
+			RecipientId:   rx.RecipientId,
+			Name:          rx.Name,
+			Email:         email,
+			RoutingOrder:  rx.RoutingOrder,
+			Status:        rx.Status,
+		})
+	}
+	for _, x := range r.Agents {
+		add("agent", x.Recipient, x.Email)
+	}
+	for _, x := range r.CarbonCopies {
+		add("carbonCopy", x.Recipient, x.Email)
+	}
+	for _, x := range r.CertifiedDeliveries {
+		add("certifiedDelivery", x.Recipient, x.Email)
+	}
+	for _, x := range r.Editors {
+		add("editor", x.Recipient, x.Email)
+	}
+	for _, x := range r.InPersonSigners {
+		add("inPersonSigner", x.Recipient, x.HostEmail)
+		v[len(v)-1].SignedDateTime = x.SignedDateTime
+	}
+	for _, x := range r.Intermediaries {
+		add("intermediary", x.Recipient, x.Email)
+	}
+	for _, x := range r.Seals {
+		v = append(v, RecipientSummary{
+			RecipientType:  "seal",
+			RecipientId:    x.RecipientId,
+			Name:           x.Name,
+			RoutingOrder:   x.RoutingOrder,
+			Status:         x.Status,
+			SignedDateTime: x.CompletedDateTime,
+		})
+	}
+	for _, x := range r.Signers {
+		add("signer", x.Recipient, x.Email)
+		v[len(v)-1].SignedDateTime = x.SignedDateTime
+	}
+	sort.Stable(byRoutingOrder(v))
+	return v
+}
+
+// byRoutingOrder sorts recipient summaries by numeric routing order.
+// An empty or invalid routing order sorts as 1, the api default.
+type byRoutingOrder []RecipientSummary
+
+func (b byRoutingOrder) Len() int           { return len(b) }
+func (b byRoutingOrder) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byRoutingOrder) Less(i, j int) bool { return b.order(i) < b.order(j) }
+
+func (b byRoutingOrder) order(i int) int {
+	if n, err := strconv.Atoi(b[i].RoutingOrder); err == nil {
+		return n
+	}
+	return 1
+}
+
 // EmailNotification contains the email message sent to a
 // recipient.  If not set, the envelopes EmailBlurb and
 // EmailSubject are used.
@@ -100,7 +202,7 @@ type SamlAuthentication struct {
 type RecipientAttachment struct {
 	Label          string `json:"label,omitempty"`
 	AttachmentType string `json:"attachmentType,omitempty"`
-	Data           string `json:"label,omitempty"`
+	Data           string `json:"data,omitempty"`
 }
 
 type SmsAuthentication struct {
@@ -109,32 +211,84 @@ type SmsAuthentication struct {
 
 // Recipient contains the common fields for all recipient types
 type Recipient struct {
-	Name                                  string               `json:"name,omitempty"`
-	AccessCode                            string               `json:"accessCode,omitempty"`
-	AddAccessCodeToEmail                  DSBool               `json:"addAccessCodeToEmail,omitempty"`
-	ClientUserId                          string               `json:"clientUserId,omitempty"`
-	EmbeddedRecipientStartURL             string               `json:"embeddedRecipientStartURL,omitempty"`
-	CustomFields                          string               `json:"customFields,omitempty"`
-	EmailNotification                     *EmailNotification   `json:"emailNotification,omitempty"`
-	ExcludedDocuments                     string               `json:"excludedDocuments,omitempty"`
-	IdCheckConfigurationName              string               `json:"idCheckConfigurationName,omitempty"`
-	IDCheckInformationInput               string               `json:"iDCheckInformationInput,omitempty"`
-	InheritEmailNotificationConfiguration DSBool               `json:"inheritEmailNotificationConfiguration,omitempty"`
-	Note                                  string               `json:"note,omitempty"`
-	PhoneAuthentication                   *PhoneAuthentication `json:"phoneAuthentication,omitempty"`
-	RecipientAttachments                  *RecipientAttachment `json:"recipientAttachment,omitempty"`
-	RecipientCaptiveInfo                  string               `json:"recipientCaptiveInfo,omitempty"`
-	RecipientId                           string               `json:"recipientId,omitempty"`
-	RequireIdLookup                       DSBool               `json:"requireIdLookup,omitempty"`
-	RoleName                              string               `json:"roleName,omitempty"`
-	RoutingOrder                          string               `json:"routingOrder,omitempty"`
-	SamlAuthentication                    *SamlAuthentication  `json:"samlAuthentication,omitempty"`
-	SmsAuthentication                     *SmsAuthentication   `json:"smsAuthentication,omitempty"`
-	SocialAuthentications                 DSBool               `json:"socialAuthentications,omitempty"`
-	TemplateAccessCodeRequired            DSBool               `json:"templateAccessCodeRequired,omitempty"`
-	TemplateLocked                        DSBool               `json:"templateLocked,omitempty"`
-	TemplateRequired                      DSBool               `json:"templateRequired,omitempty"`
-	ErrorDetails                          *ResponseError       `json:"errorDetails,omitempty"`
+	Name                                  string                         `json:"name,omitempty"`
+	AccessCode                            string                         `json:"accessCode,omitempty"`
+	AddAccessCodeToEmail                  DSBool                         `json:"addAccessCodeToEmail,omitempty"`
+	ClientUserId                          string                         `json:"clientUserId,omitempty"`
+	EmbeddedRecipientStartURL             string                         `json:"embeddedRecipientStartURL,omitempty"`
+	CustomFields                          string                         `json:"customFields,omitempty"`
+	EmailNotification                     *EmailNotification             `json:"emailNotification,omitempty"`
+	ExcludedDocuments                     []string                       `json:"excludedDocuments,omitempty"`
+	IdCheckConfigurationName              string                         `json:"idCheckConfigurationName,omitempty"`
+	IDCheckInformationInput               *IDCheckInformationInput       `json:"idCheckInformationInput,omitempty"`
+	InheritEmailNotificationConfiguration DSBool                         `json:"inheritEmailNotificationConfiguration,omitempty"`
+	Note                                  string                         `json:"note,omitempty"`
+	PhoneAuthentication                   *PhoneAuthentication           `json:"phoneAuthentication,omitempty"`
+	RecipientAttachments                  *RecipientAttachment           `json:"recipientAttachment,omitempty"`
+	RecipientAuthenticationStatus         *RecipientAuthenticationStatus `json:"recipientAuthenticationStatus,omitempty"`
+	RecipientCaptiveInfo                  string                         `json:"recipientCaptiveInfo,omitempty"`
+	RecipientId                           string                         `json:"recipientId,omitempty"`
+	RequireIdLookup                       DSBool                         `json:"requireIdLookup,omitempty"`
+	RoleName                              string                         `json:"roleName,omitempty"`
+	RoutingOrder                          string                         `json:"routingOrder,omitempty"`
+	SamlAuthentication                    *SamlAuthentication            `json:"samlAuthentication,omitempty"`
+	SigningGroupId                        string                         `json:"signingGroupId,omitempty"`
+	SigningGroupName                      string                         `json:"signingGroupName,omitempty"`
+	SmsAuthentication                     *SmsAuthentication             `json:"smsAuthentication,omitempty"`
+	SocialAuthentications                 DSBool                         `json:"socialAuthentications,omitempty"`
+	Status                                string                         `json:"status,omitempty"`
+	TemplateAccessCodeRequired            DSBool                         `json:"templateAccessCodeRequired,omitempty"`
+	TemplateLocked                        DSBool                         `json:"templateLocked,omitempty"`
+	TemplateRequired                      DSBool                         `json:"templateRequired,omitempty"`
+	UserId                                string                         `json:"userId,omitempty"`
+	ErrorDetails                          *ResponseError                 `json:"errorDetails,omitempty"`
+}
+
+// RecipientAuthenticationStatus contains the results of each
+// authentication performed by a recipient.  It is returned when
+// reading recipients with RecipientsIncludeExtended.
+type RecipientAuthenticationStatus struct {
+	AccessCodeResult           *AuthenticationResult `json:"accessCodeResult,omitempty"`
+	AgeVerifyResult            *AuthenticationResult `json:"ageVerifyResult,omitempty"`
+	AnySocialIDResult          *AuthenticationResult `json:"anySocialIDResult,omitempty"`
+	FacebookResult             *AuthenticationResult `json:"facebookResult,omitempty"`
+	GoogleResult               *AuthenticationResult `json:"googleResult,omitempty"`
+	IdLookupResult             *AuthenticationResult `json:"idLookupResult,omitempty"`
+	IdQuestionsResult          *AuthenticationResult `json:"idQuestionsResult,omitempty"`
+	IdentityVerificationResult *AuthenticationResult `json:"identityVerificationResult,omitempty"`
+	LinkedinResult             *AuthenticationResult `json:"linkedinResult,omitempty"`
+	LiveIDResult               *AuthenticationResult `json:"liveIDResult,omitempty"`
+	OfacResult                 *AuthenticationResult `json:"ofacResult,omitempty"`
+	OpenIDResult               *AuthenticationResult `json:"openIDResult,omitempty"`
+	PhoneAuthResult            *AuthenticationResult `json:"phoneAuthResult,omitempty"`
+	SalesforceResult           *AuthenticationResult `json:"salesforceResult,omitempty"`
+	SignatureProviderResult    *AuthenticationResult `json:"signatureProviderResult,omitempty"`
+	SmsAuthResult              *AuthenticationResult `json:"smsAuthResult,omitempty"`
+	STANPinResult              *AuthenticationResult `json:"sTANPinResult,omitempty"`
+	TwitterResult              *AuthenticationResult `json:"twitterResult,omitempty"`
+	YahooResult                *AuthenticationResult `json:"yahooResult,omitempty"`
+}
+
+// Failed returns true if any authentication result has a Failed status.
+func (a RecipientAuthenticationStatus) Failed() bool {
+	for _, r := range []*AuthenticationResult{a.AccessCodeResult, a.AgeVerifyResult, a.AnySocialIDResult,
+		a.FacebookResult, a.GoogleResult, a.IdLookupResult, a.IdQuestionsResult, a.IdentityVerificationResult,
+		a.LinkedinResult, a.LiveIDResult, a.OfacResult, a.OpenIDResult, a.PhoneAuthResult, a.SalesforceResult,
+		a.SignatureProviderResult, a.SmsAuthResult, a.STANPinResult, a.TwitterResult, a.YahooResult} {
+		if r != nil && strings.EqualFold(r.Status, "Failed") {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthenticationResult is the outcome of a recipient authentication.
+// Status is "Passed" or "Failed".
+type AuthenticationResult struct {
+	EventTimestamp          DSTime `json:"eventTimestamp,omitempty"`
+	FailureDescription      string `json:"failureDescription,omitempty"`
+	Status                  string `json:"status,omitempty"`
+	VendorFailureStatusCode string `json:"vendorFailureStatusCode,omitempty"`
 }
 
 // EmailRecipient adds email field to base recipient structure
@@ -182,8 +336,9 @@ type Editor struct {
 type InPersonSigner struct {
 	Recipient
 	BaseSigner
-	HostEmail string `json:"hostEmail,omitempty"`
-	HostName  string `json:"hostName,omitempty"`
+	HostEmail      string `json:"hostEmail,omitempty"`
+	HostName       string `json:"hostName,omitempty"`
+	SignedDateTime DSTime `json:"signedDateTime,omitempty"`
 }
 
 // This recipient can, but is not required to, add name and email information for recipients at the same or subsequent level in the routing order
@@ -196,6 +351,43 @@ type Intermediary struct {
 	CanEditRecipientNames  DSBool `json:"canEditRecipientNames,omitempty"`
 }
 
+// SealSign applies an electronic seal to the envelope's documents
+// without a person signing.  The seal is configured by the
+// RecipientSignatureProviders.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Recipients/Seals%20Recipient.htm
+type SealSign struct {
+	RecipientId                 string                       `json:"recipientId,omitempty"`
+	RecipientIdGuid             string                       `json:"recipientIdGuid,omitempty"`
+	RoutingOrder                string                       `json:"routingOrder,omitempty"`
+	Name                        string                       `json:"name,omitempty"`
+	Status                      string                       `json:"status,omitempty"`
+	DeliveryMethod              string                       `json:"deliveryMethod,omitempty"`
+	RecipientSignatureProviders []RecipientSignatureProvider `json:"recipientSignatureProviders,omitempty"`
+	CompletedDateTime           DSTime                       `json:"completedDateTime,omitempty"`
+	ErrorDetails                *ResponseError               `json:"errorDetails,omitempty"`
+}
+
+// RecipientSignatureProvider selects the signature provider used to
+// seal or sign documents.  Available providers are listed by
+// Service.SignatureProviders.
+type RecipientSignatureProvider struct {
+	SealDocumentsWithTabsOnly DSBool                    `json:"sealDocumentsWithTabsOnly,omitempty"`
+	SealName                  string                    `json:"sealName,omitempty"`
+	SignatureProviderName     string                    `json:"signatureProviderName,omitempty"`
+	SignatureProviderOptions  *SignatureProviderOptions `json:"signatureProviderOptions,omitempty"`
+}
+
+// SignatureProviderOptions contains the provider specific values
+// needed to authenticate the signer.
+type SignatureProviderOptions struct {
+	CpfNumber       string `json:"cpfNumber,omitempty"`
+	OneTimePassword string `json:"oneTimePassword,omitempty"`
+	SignerRole      string `json:"signerRole,omitempty"`
+	Sms             string `json:"sms,omitempty"`
+}
+
 // BaseSigner contains common fields of all signer types
 type BaseSigner struct {
 	AutoNavigation     string `json:"autoNavigation,omitempty"`
@@ -213,16 +405,180 @@ type BaseSigner struct {
 type Signer struct {
 	EmailRecipient
 	BaseSigner
-	IsBulkRecipient   string            `json:"isBulkRecipient,omitempty"`
-	BulkRecipientsUri string            `json:"bulkRecipientsUri,omitempty"`
-	DeliveryMethod    string            `json:"deliveryMethod,omitempty"`
-	DeliveredDateTime string            `json:"deliveredDateTime,omitempty"`
-	SignedDateTime    string            `json:"signedDateTime,omitempty"`
-	OfflineAttributes map[string]string `json:"offlineAttributes,omitempty"`
+	IsBulkRecipient             string                       `json:"isBulkRecipient,omitempty"`
+	BulkRecipientsUri           string                       `json:"bulkRecipientsUri,omitempty"`
+	DeliveryMethod              string                       `json:"deliveryMethod,omitempty"`
+	PhoneNumber                 *PhoneNumber                 `json:"phoneNumber,omitempty"`
+	SentDateTime                DSTime                       `json:"sentDateTime,omitempty"`
+	DeliveredDateTime           DSTime                       `json:"deliveredDateTime,omitempty"`
+	SignedDateTime              DSTime                       `json:"signedDateTime,omitempty"`
+	DeclinedDateTime            DSTime                       `json:"declinedDateTime,omitempty"`
+	OfflineAttributes           *OfflineAttributes           `json:"offlineAttributes,omitempty"`
+	RecipientSignatureProviders []RecipientSignatureProvider `json:"recipientSignatureProviders,omitempty"`
+}
+
+// Delivery methods for Signer.DeliveryMethod.  SMS and WhatsApp
+// delivery require the signer's PhoneNumber.
+const (
+	DeliveryMethodEmail    = "email"
+	DeliveryMethodFax      = "fax"
+	DeliveryMethodOffline  = "offline"
+	DeliveryMethodSMS      = "SMS"
+	DeliveryMethodWhatsApp = "WhatsApp"
+)
+
+// PhoneNumber is the mobile number receiving a signer's
+// notifications when delivered by SMS or WhatsApp.
+type PhoneNumber struct {
+	CountryCode string `json:"countryCode,omitempty"`
+	Number      string `json:"number,omitempty"`
+}
+
+// validateDelivery returns an error if a signer delivered by
+// SMS or WhatsApp has no phone number.
+func (r RecipientList) validateDelivery() error {
+	for _, x := range r.Signers {
+		if !strings.EqualFold(x.DeliveryMethod, DeliveryMethodSMS) &&
+			!strings.EqualFold(x.DeliveryMethod, DeliveryMethodWhatsApp) {
+			continue
+		}
+		if x.PhoneNumber == nil || x.PhoneNumber.Number == "" {
+			return fmt.Errorf("docusign: signer %s has delivery method %s but no phone number", x.RecipientId, x.DeliveryMethod)
+		}
+	}
+	return nil
+}
+
+// OfflineAttributes reports the device information for a
+// signer who signed offline using the mobile SDK.
+type OfflineAttributes struct {
+	AccountEsignId     string `json:"accountEsignId,omitempty"`
+	DeviceModel        string `json:"deviceModel,omitempty"`
+	DeviceName         string `json:"deviceName,omitempty"`
+	GpsLatitude        string `json:"gpsLatitude,omitempty"`
+	GpsLongitude       string `json:"gpsLongitude,omitempty"`
+	OfflineSigningHash string `json:"offlineSigningHash,omitempty"`
+	SignatureType      string `json:"signatureType,omitempty"`
+}
+
+// NewOfflineAttributes converts the map previously used for
+// Signer.OfflineAttributes.  Unknown keys are ignored.
+func NewOfflineAttributes(m map[string]string) *OfflineAttributes {
+	if m == nil {
+		return nil
+	}
+	return &OfflineAttributes{
+		AccountEsignId:     m["accountEsignId"],
+		DeviceModel:        m["deviceModel"],
+		DeviceName:         m["deviceName"],
+		GpsLatitude:        m["gpsLatitude"],
+		GpsLongitude:       m["gpsLongitude"],
+		OfflineSigningHash: m["offlineSigningHash"],
+		SignatureType:      m["signatureType"],
+	}
+}
+
+// Map returns the attributes as a map keyed by json name.  Empty
+// values are omitted.
+func (o *OfflineAttributes) Map() map[string]string {
+	m := make(map[string]string)
+	if o == nil {
+		return m
+	}
+	for k, v := range map[string]string{
+		"accountEsignId":     o.AccountEsignId,
+		"deviceModel":        o.DeviceModel,
+		"deviceName":         o.DeviceName,
+		"gpsLatitude":        o.GpsLatitude,
+		"gpsLongitude":       o.GpsLongitude,
+		"offlineSigningHash": o.OfflineSigningHash,
+		"signatureType":      o.SignatureType,
+	} {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// BulkRecipientList contains the bulk recipients of a bulk send
+// signer.  See BulkRecipients and BulkRecipientsAdd.
+//
+// RestApi Documentation
+// https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Get%20Bulk%20Recipients.htm
+type BulkRecipientList struct {
+	BulkRecipients      []BulkRecipient `json:"bulkRecipients,omitempty"`
+	BulkRecipientsCount string          `json:"bulkRecipientsCount,omitempty"`
+	BulkRecipientsUri   string          `json:"bulkRecipientsUri,omitempty"`
+	StartPosition       string          `json:"startPosition,omitempty"`
+	EndPosition         string          `json:"endPosition,omitempty"`
+	ResultSetSize       string          `json:"resultSetSize,omitempty"`
+	TotalSetSize        string          `json:"totalSetSize,omitempty"`
+	NextUri             string          `json:"nextUri,omitempty"`
+	PreviousUri         string          `json:"previousUri,omitempty"`
+	ErrorDetails        []ResponseError `json:"errorDetails,omitempty"`
+}
+
+// BulkRecipient is a single recipient of a bulk send.  TabLabels
+// contains the values of the signer's custom tabs.
+type BulkRecipient struct {
+	RowNumber      string          `json:"rowNumber,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	Email          string          `json:"email,omitempty"`
+	Note           string          `json:"note,omitempty"`
+	AccessCode     string          `json:"accessCode,omitempty"`
+	Identification string          `json:"identification,omitempty"`
+	PhoneNumber    string          `json:"phoneNumber,omitempty"`
+	TabLabels      []NmVal         `json:"tabLabels,omitempty"`
+	ErrorDetails   []ResponseError `json:"errorDetails,omitempty"`
+}
+
+// csv formats the list as the csv file expected by the bulk
+// recipients upload.  Each distinct tab label becomes a column.
+func (b *BulkRecipientList) csv() ([]byte, error) {
+	hdr := []string{"Name", "Email", "AccessCode", "Identification", "Phone", "Note"}
+	col := make(map[string]int)
+	for _, r := range b.BulkRecipients {
+		for _, nv := range r.TabLabels {
+			if _, ok := col[nv.Name]; !ok {
+				col[nv.Name] = len(hdr)
+				hdr = append(hdr, nv.Name)
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Write(hdr)
+	for _, r := range b.BulkRecipients {
+		row := make([]string, len(hdr))
+		copy(row, []string{r.Name, r.Email, r.AccessCode, r.Identification, r.PhoneNumber, r.Note})
+		for _, nv := range r.TabLabels {
+			row[col[nv.Name]] = nv.Value
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
 }
 
 // RecipeintUpdateResult is returned via the RecipientsModify call and returns
 // a list of recipient ids and a corresponding error detail for each modification.
 type RecipientUpdateResult struct {
-	recipientUpdateResults []Recipient `json:"recipientUpdateResults"`
+	RecipientUpdateResults []Recipient `json:"recipientUpdateResults"`
+}
+
+// DocumentVisibilityList contains the visibility of envelope documents
+// to recipients.
+type DocumentVisibilityList struct {
+	DocumentVisibility []DocumentVisibility `json:"documentVisibility,omitempty"`
+}
+
+// DocumentVisibility sets whether a recipient may see a document.  Rights
+// is either "editable" or "read_only".
+type DocumentVisibility struct {
+	DocumentId   string         `json:"documentId,omitempty"`
+	RecipientId  string         `json:"recipientId,omitempty"`
+	Rights       string         `json:"rights,omitempty"`
+	Visible      DSBool         `json:"visible,omitempty"`
+	ErrorDetails *ResponseError `json:"errorDetails,omitempty"`
 }
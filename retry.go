@@ -0,0 +1,108 @@
+// Copyright 2015 James Cote and Liberty Fund, Inc.
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docusign
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy determines how a Service retries failed calls.  Only GET
+// and HEAD calls are retried unless RetryPutDelete is set, and calls
+// that upload files are never retried as their bodies cannot be
+// replayed.  A call is retried when docusign returns one of the
+// following statuses:
+//
+//	429 Too Many Requests
+//	500 Internal Server Error
+//	502 Bad Gateway
+//	503 Service Unavailable
+//	504 Gateway Timeout
+//
+// The delay before a retry is taken from the response's Retry-After
+// header when present.  Otherwise the delay starts at MinBackoff and
+// doubles with each attempt.  The delay never exceeds MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the
+	// original request.
+	MaxAttempts int
+	// MinBackoff defaults to one second.
+	MinBackoff time.Duration
+	// MaxBackoff defaults to one minute.
+	MaxBackoff time.Duration
+	// RetryPutDelete allows PUT and DELETE calls to be retried.  Some
+	// PUT calls, such as EnvelopeResend, send emails again when retried.
+	RetryPutDelete bool
+}
+
+// delay returns the time to wait before retrying the call.  The
+// bool result is false if the call should not be retried.
+func (p *RetryPolicy) delay(c *Call, attempt int, res *http.Response) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || !retryableStatus(res.StatusCode) {
+		return 0, false
+	}
+	switch c.Method {
+	case "GET", "HEAD":
+	case "PUT", "DELETE":
+		if !p.RetryPutDelete {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if _, ok := c.Payload.(*UploadFile); ok || len(c.Files) > 0 {
+		return 0, false
+	}
+	wait, max := p.MinBackoff, p.MaxBackoff
+	if wait <= 0 {
+		wait = time.Second
+	}
+	if max <= 0 {
+		max = time.Minute
+	}
+	if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+		if after > max {
+			after = max
+		}
+		return after, true
+	}
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait, true
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header value of either delay
+// seconds or an http date.
+func retryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if tm, err := http.ParseTime(val); err == nil {
+		wait := tm.Sub(time.Now())
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -6,6 +6,9 @@
 package docusign
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -31,6 +34,51 @@ const ReturnUrlTypeSigningComplete = "signing_complete"
 const ReturnUrlTypeTTLExpired = "ttl_expired"
 const ReturnUrlTypeViewComplete = "view_complete"
 
+// AuthMethod is the method used by the client application to authenticate
+// a recipient before a recipient view.
+type AuthMethod string
+
+const (
+	AuthMethodBiometric       AuthMethod = "Biometric"
+	AuthMethodEmail           AuthMethod = "Email"
+	AuthMethodHTTPBasicAuth   AuthMethod = "HTTPBasicAuth"
+	AuthMethodKerberos        AuthMethod = "Kerberos"
+	AuthMethodKBA             AuthMethod = "KnowledgeBasedAuth"
+	AuthMethodNone            AuthMethod = "None"
+	AuthMethodPaperDocuments  AuthMethod = "PaperDocuments"
+	AuthMethodPassword        AuthMethod = "Password"
+	AuthMethodRSASecureID     AuthMethod = "RSASecureID"
+	AuthMethodSSOCASiteminder AuthMethod = "SingleSignOn_CASiteminder"
+	AuthMethodSSOInfoCard     AuthMethod = "SingleSignOn_InfoCard"
+	AuthMethodSSOOther        AuthMethod = "SingleSignOn_Other"
+	AuthMethodSSOPassport     AuthMethod = "SingleSignOn_Passport"
+	AuthMethodSSOSAML         AuthMethod = "SingleSignOn_SAML"
+	AuthMethodSmartcard       AuthMethod = "Smartcard"
+	AuthMethodSSLMutualAuth   AuthMethod = "SSLMutualAuth"
+	AuthMethodX509Certificate AuthMethod = "X509Certificate"
+)
+
+var authMethods = map[string]bool{}
+
+func init() {
+	for _, m := range []AuthMethod{AuthMethodBiometric, AuthMethodEmail, AuthMethodHTTPBasicAuth,
+		AuthMethodKerberos, AuthMethodKBA, AuthMethodNone, AuthMethodPaperDocuments, AuthMethodPassword,
+		AuthMethodRSASecureID, AuthMethodSSOCASiteminder, AuthMethodSSOInfoCard, AuthMethodSSOOther,
+		AuthMethodSSOPassport, AuthMethodSSOSAML, AuthMethodSmartcard,
+		AuthMethodSSLMutualAuth, AuthMethodX509Certificate} {
+		authMethods[strings.ToLower(string(m))] = true
+	}
+}
+
+// Valid returns nil if m is a method accepted by docusign.  Values
+// are compared without regard to case.
+func (m AuthMethod) Valid() error {
+	if !authMethods[strings.ToLower(string(m))] {
+		return fmt.Errorf("docusign: unknown authentication method %q", m)
+	}
+	return nil
+}
+
 // EnvUrl is a url for an envelope view.
 type EnvUrl struct {
 	Url string `json:"url,omitempty"`
@@ -38,9 +86,15 @@ type EnvUrl struct {
 
 // EnvRecipientView is used to create a url for a recipient.
 // See PostRecipientView
+//
+// To display the signing session in an iframe, FrameAncestors must list
+// the origins of the embedding page plus the docusign signing origin
+// (e.g. https://apps-d.docusign.com for demo), and MessageOrigins must
+// contain that docusign origin.  PingUrl and PingFrequency (seconds)
+// keep the embedding application's session alive while signing.
 type EnvRecipientView struct {
 	ClientUserId          string        `json:"clientUserId,omitempty"`
-	AuthenticationMethod  string        `json:"authenticationMethod,omitempty"`
+	AuthenticationMethod  AuthMethod    `json:"authenticationMethod,omitempty"`
 	AssertionId           string        `json:"assertionId,omitempty"`
 	AuthenticationInstant time.Time     `json:"authenticationInstant,omitempty"`
 	SecurityDomain        string        `json:"securityDomain,omitempty"`
@@ -48,6 +102,10 @@ type EnvRecipientView struct {
 	UserId                string        `json:"userId,omitempty"`
 	UserName              string        `json:"userName,omitempty"`
 	ReturnUrl             ReturnUrlType `json:"returnUrl,omitempty"`
+	FrameAncestors        []string      `json:"frameAncestors,omitempty"`
+	MessageOrigins        []string      `json:"messageOrigins,omitempty"`
+	PingFrequency         string        `json:"pingFrequency,omitempty"`
+	PingUrl               string        `json:"pingUrl,omitempty"`
 }
 
 // FolderEnvList is the response struct for Serivice.GetFolderEnvList()
@@ -92,17 +150,17 @@ type Folder struct {
 
 // FolderItem describes an envelope in a FolderEnvList
 type FolderItem struct {
-	Name            string        `json:"name,omitempty"`
-	CreatedDateTime time.Time     `json:"createdDateTime,omitempty"`
-	EnvelopeId      string        `json:"envelopeId,omitempty"`
-	EnvelopeUri     string        `json:"envelopeUri,omitempty"`
-	OwnerName       string        `json:"ownerName,omitempty"`
-	SenderEmail     string        `json:"senderEmail,omitempty"`
-	SenderName      string        `json:"senderName,omitempty"`
-	SentDateTime    time.Time     `json:"sentDateTime,omitempty"`
-	Status          string        `json:"status,omitempty"`
-	Subject         string        `json:"subject,omitempty"`
-	Recipients      RecipientList `json:"recipients,omitempty"`
+	Name            string         `json:"name,omitempty"`
+	CreatedDateTime time.Time      `json:"createdDateTime,omitempty"`
+	EnvelopeId      string         `json:"envelopeId,omitempty"`
+	EnvelopeUri     string         `json:"envelopeUri,omitempty"`
+	OwnerName       string         `json:"ownerName,omitempty"`
+	SenderEmail     string         `json:"senderEmail,omitempty"`
+	SenderName      string         `json:"senderName,omitempty"`
+	SentDateTime    time.Time      `json:"sentDateTime,omitempty"`
+	Status          EnvelopeStatus `json:"status,omitempty"`
+	Subject         string         `json:"subject,omitempty"`
+	Recipients      RecipientList  `json:"recipients,omitempty"`
 }
 
 // Notificaton is the response struct for GetEnvelopeNotification
@@ -112,18 +170,137 @@ type Notification struct {
 	Expirations        *Expiration `json:"expirations,omitempty"`
 }
 
+// EnvelopeWorkflow contains the scheduled sending and delayed routing
+// steps of an envelope.
+type EnvelopeWorkflow struct {
+	CurrentWorkflowStepId string            `json:"currentWorkflowStepId,omitempty"`
+	ResumeDate            string            `json:"resumeDate,omitempty"`
+	ScheduledSending      *ScheduledSending `json:"scheduledSending,omitempty"`
+	WorkflowStatus        string            `json:"workflowStatus,omitempty"`
+	WorkflowSteps         []WorkflowStep    `json:"workflowSteps,omitempty"`
+}
+
+// Envelope workflow status values.
+const (
+	WorkflowStatusInProgress = "in_progress"
+	WorkflowStatusPaused     = "paused"
+)
+
+// WorkflowStep pauses the envelope before a routing order.  Set Action to
+// "pause_before", TriggerOnItem to "routing_order" and ItemId to the
+// routing order.
+type WorkflowStep struct {
+	WorkflowStepId string          `json:"workflowStepId,omitempty"`
+	Action         string          `json:"action,omitempty"`
+	ItemId         string          `json:"itemId,omitempty"`
+	TriggerOnItem  string          `json:"triggerOnItem,omitempty"`
+	DelayedRouting *DelayedRouting `json:"delayedRouting,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	CompletedDate  string          `json:"completedDate,omitempty"`
+	TriggeredDate  string          `json:"triggeredDate,omitempty"`
+}
+
+// DelayedRouting delays routing to the next recipients by its Rules.
+type DelayedRouting struct {
+	ResumeDate string              `json:"resumeDate,omitempty"`
+	Rules      []EnvelopeDelayRule `json:"rules,omitempty"`
+	Status     string              `json:"status,omitempty"`
+}
+
+// ScheduledSending delays sending of the envelope by its Rules.
+type ScheduledSending struct {
+	BulkListId string              `json:"bulkListId,omitempty"`
+	ResumeDate string              `json:"resumeDate,omitempty"`
+	Rules      []EnvelopeDelayRule `json:"rules,omitempty"`
+	Status     string              `json:"status,omitempty"`
+}
+
+// EnvelopeDelayRule specifies either a Delay (formatted d.hh:mm:ss) or a
+// ResumeDate (formatted as RFC3339).
+type EnvelopeDelayRule struct {
+	Delay      string `json:"delay,omitempty"`
+	ResumeDate string `json:"resumeDate,omitempty"`
+}
+
+// ResumeAt returns a rule resuming the envelope at t.  The time zone
+// offset of t is kept.
+func ResumeAt(t time.Time) EnvelopeDelayRule {
+	return EnvelopeDelayRule{ResumeDate: t.Format(time.RFC3339)}
+}
+
+// ResumeAfter returns a rule resuming the envelope after d, which is
+// rounded down to seconds.
+func ResumeAfter(d time.Duration) EnvelopeDelayRule {
+	secs := int64(d / time.Second)
+	return EnvelopeDelayRule{Delay: fmt.Sprintf("%d.%02d:%02d:%02d", secs/86400, secs/3600%24, secs/60%60, secs%60)}
+}
+
 type Reminder struct {
 	ReminderEnabled   string `json:"reminderEnabled,omitempty"`
 	ReminderDelay     string `json:"reminderDelay,omitempty"`     // Number of days
 	ReminderFrequency string `json:"reminderFrequency,omitempty"` // Number of intervals
 }
 
+// NewReminder returns an enabled Reminder sending the first reminder after
+// delayDays and then every frequencyDays.
+func NewReminder(delayDays, frequencyDays int) *Reminder {
+	return &Reminder{
+		ReminderEnabled:   "true",
+		ReminderDelay:     strconv.Itoa(delayDays),
+		ReminderFrequency: strconv.Itoa(frequencyDays),
+	}
+}
+
+// DelayDays returns ReminderDelay as an int.  An empty value returns 0.
+func (r Reminder) DelayDays() (int, error) {
+	return atoiDays("reminderDelay", r.ReminderDelay)
+}
+
+// FrequencyDays returns ReminderFrequency as an int.  An empty value returns 0.
+func (r Reminder) FrequencyDays() (int, error) {
+	return atoiDays("reminderFrequency", r.ReminderFrequency)
+}
+
 type Expiration struct {
 	ExpireEnabled string `json:"expireEnabled,omitempty"`
 	ExpireAfter   string `json:"expireAfter,omitempty"` // Number of days until expiration
 	ExpireWarn    string `json:"expireWarn,omitempty"`  // Number of days until warning
 }
 
+// NewExpiration returns an enabled Expiration expiring the envelope after
+// afterDays and warning recipients warnDays before expiration.
+func NewExpiration(afterDays, warnDays int) *Expiration {
+	return &Expiration{
+		ExpireEnabled: "true",
+		ExpireAfter:   strconv.Itoa(afterDays),
+		ExpireWarn:    strconv.Itoa(warnDays),
+	}
+}
+
+// AfterDays returns ExpireAfter as an int.  An empty value returns 0.
+func (e Expiration) AfterDays() (int, error) {
+	return atoiDays("expireAfter", e.ExpireAfter)
+}
+
+// WarnDays returns ExpireWarn as an int.  An empty value returns 0.
+func (e Expiration) WarnDays() (int, error) {
+	return atoiDays("expireWarn", e.ExpireWarn)
+}
+
+// atoiDays parses the day count of the named field.
+func atoiDays(name, val string) (int, error) {
+	if val == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("docusign: invalid %s %q", name, val)
+	}
+	return days, nil
+}
+
+// BccEmail is an address copied on all envelope emails.  Use
+// BccEmailAddressId to identify an existing address in an update.
 type BccEmail struct {
 	BccEmailAddressId string `json:"bccEmailAddressId,omitempty"`
 	Email             string `json:"email,omitempty"`
@@ -139,14 +316,32 @@ type InlineTemplate struct {
 	Documents  []Document     `json:"documents,omitempty"`
 	Recipients *RecipientList `json:"recipients,omitempty"`
 }
+
+// CompositeTemplate combines server and inline templates.  Document is a
+// single document replacing the documents of the templates; documents
+// added by an inline template belong in its Documents.
 type CompositeTemplate struct {
 	CompositeTemplateId         string           `json:"compositeTemplateId,omitempty"`
 	ServerTemplates             []ServerTemplate `json:"serverTemplates,omitempty"`
 	InlineTemplates             []InlineTemplate `json:"inlineTemplates,omitempty"`
 	PdfMetaDataTemplateSequence string           `json:"pdfMetaDataTemplateSequence,omitempty"`
-	Document                    Document         `json:"document,omitempty"`
+	Document                    *Document        `json:"document,omitempty"`
 }
 
+// EnvelopeStatus describes the state of an envelope.  It is an alias of
+// string so that existing string values remain assignable.
+type EnvelopeStatus = string
+
+const (
+	StatusCreated   EnvelopeStatus = "created"
+	StatusSent      EnvelopeStatus = "sent"
+	StatusDelivered EnvelopeStatus = "delivered"
+	StatusSigned    EnvelopeStatus = "signed"
+	StatusCompleted EnvelopeStatus = "completed"
+	StatusDeclined  EnvelopeStatus = "declined"
+	StatusVoided    EnvelopeStatus = "voided"
+)
+
 // https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Send%20an%20Envelope.htm
 type Envelope struct {
 	Accessibility           string              `json:"accessibility,omitempty"`
@@ -166,7 +361,7 @@ type Envelope struct {
 	Notification            *Notification       `json:"notification,omitempty"`
 	RecipientsLock          string              `json:"recipientsLock,omitempty"`
 	SigningLocation         string              `json:"signingLocation,omitempty"`
-	Status                  string              `json:"status,omitempty"`
+	Status                  EnvelopeStatus      `json:"status,omitempty"`
 	TransactionId           string              `json:"transactionId,omitempty"`
 	UseDisclosure           bool                `json:"useDisclosure,omitempty"`
 	CustomFields            *CustomFieldList    `json:"customFields,omitempty"`
@@ -177,6 +372,40 @@ type Envelope struct {
 	TemplateId              string              `json:"templateId,omitempty"`
 	TemplateRoles           []TemplateRole      `json:"templateRoles,omitempty"`
 	CompositeTemplates      []CompositeTemplate `json:"compositeTemplates,omitempty"`
+	Workflow                *EnvelopeWorkflow   `json:"workflow,omitempty"`
+
+	// read only fields returned by EnvelopeGet
+	EnvelopeId            string `json:"envelopeId,omitempty"`
+	EnvelopeUri           string `json:"envelopeUri,omitempty"`
+	CreatedDateTime       DSTime `json:"createdDateTime,omitempty"`
+	SentDateTime          DSTime `json:"sentDateTime,omitempty"`
+	DeliveredDateTime     DSTime `json:"deliveredDateTime,omitempty"`
+	CompletedDateTime     DSTime `json:"completedDateTime,omitempty"`
+	DeclinedDateTime      DSTime `json:"declinedDateTime,omitempty"`
+	VoidedDateTime        DSTime `json:"voidedDateTime,omitempty"`
+	VoidedReason          string `json:"voidedReason,omitempty"`
+	StatusChangedDateTime DSTime `json:"statusChangedDateTime,omitempty"`
+}
+
+// validateDelivery checks the delivery methods of the envelope's
+// recipients and the recipients of its inline templates.
+func (e *Envelope) validateDelivery() error {
+	if e.Recipients != nil {
+		if err := e.Recipients.validateDelivery(); err != nil {
+			return err
+		}
+	}
+	for _, ct := range e.CompositeTemplates {
+		for _, it := range ct.InlineTemplates {
+			if it.Recipients == nil {
+				continue
+			}
+			if err := it.Recipients.validateDelivery(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 type CustomFieldList struct {
@@ -184,6 +413,64 @@ type CustomFieldList struct {
 	TextCustomFields []CustomField     `json:"textCustomFields,omitempty"`
 }
 
+// FirstError returns the ErrorDetails of the first custom field
+// reporting an error, or nil if all fields succeeded.  A nil
+// CustomFieldList has no error.
+func (c *CustomFieldList) FirstError() error {
+	if c == nil {
+		return nil
+	}
+	for _, v := range c.ListCustomFields {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range c.TextCustomFields {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	return nil
+}
+
+// Text returns the value of the named text custom field.
+func (c *CustomFieldList) Text(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, v := range c.TextCustomFields {
+		if v.Name == name {
+			return v.Value, true
+		}
+	}
+	return "", false
+}
+
+// List returns the named list custom field.
+func (c *CustomFieldList) List(name string) (*ListCustomField, bool) {
+	if c == nil {
+		return nil, false
+	}
+	for i := range c.ListCustomFields {
+		if c.ListCustomFields[i].Name == name {
+			return &c.ListCustomFields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Set updates the value of the named text custom field, adding
+// the field if it does not exist.
+func (c *CustomFieldList) Set(name, value string) {
+	for i := range c.TextCustomFields {
+		if c.TextCustomFields[i].Name == name {
+			c.TextCustomFields[i].Value = value
+			return
+		}
+	}
+	c.TextCustomFields = append(c.TextCustomFields, CustomField{Name: name, Value: value})
+}
+
 type CustomField struct {
 	Id           string         `json:"fieldId,omitempty"`
 	Name         string         `json:"name,omitempty"`
@@ -212,6 +499,68 @@ type Document struct {
 	FileExtension           string     `json:"fileExtension,omitempty"`
 	DocumentBase64          []byte     `json:"documentBase64,omitempty"`
 	Matchboxes              []Matchbox `json:"matchboxes,omitempty"`
+	// HtmlDefinition converts the document for responsive signing.
+	HtmlDefinition *DocumentHtmlDefinition `json:"htmlDefinition,omitempty"`
+}
+
+// DocumentHtmlDefinition defines how a document is displayed with responsive
+// signing.  Set Source to "document" to convert the document's content, or
+// to html content for an html document.
+//
+// RestApi documentation
+// https://developers.docusign.com/esign-rest-api/v2/guides/responsive
+type DocumentHtmlDefinition struct {
+	Source                    string                      `json:"source,omitempty"`
+	DisplayAnchorPrefix       string                      `json:"displayAnchorPrefix,omitempty"`
+	DisplayAnchors            []DocumentHtmlDisplayAnchor `json:"displayAnchors,omitempty"`
+	DisplayOrder              string                      `json:"displayOrder,omitempty"`
+	DisplayPageNumber         string                      `json:"displayPageNumber,omitempty"`
+	DocumentGuid              string                      `json:"documentGuid,omitempty"`
+	DocumentId                string                      `json:"documentId,omitempty"`
+	HeaderLabel               string                      `json:"headerLabel,omitempty"`
+	MaxScreenWidth            string                      `json:"maxScreenWidth,omitempty"`
+	RemoveEmptyTags           string                      `json:"removeEmptyTags,omitempty"`
+	ShowMobileOptimizedToggle DSBool                      `json:"showMobileOptimizedToggle,omitempty"`
+}
+
+// DocumentHtmlDisplayAnchor sets the display of the content between
+// StartAnchor and EndAnchor.
+type DocumentHtmlDisplayAnchor struct {
+	CaseSensitive     DSBool                       `json:"caseSensitive,omitempty"`
+	DisplaySettings   *DocumentHtmlDisplaySettings `json:"displaySettings,omitempty"`
+	EndAnchor         string                       `json:"endAnchor,omitempty"`
+	RemoveEndAnchor   DSBool                       `json:"removeEndAnchor,omitempty"`
+	RemoveStartAnchor DSBool                       `json:"removeStartAnchor,omitempty"`
+	StartAnchor       string                       `json:"startAnchor,omitempty"`
+}
+
+// DocumentHtmlDisplaySettings describes how anchored content is shown,
+// e.g. Display "collapsible" with a DisplayLabel.
+type DocumentHtmlDisplaySettings struct {
+	CellStyle             string `json:"cellStyle,omitempty"`
+	CollapsibleSettings   string `json:"collapsibleSettings,omitempty"`
+	Display               string `json:"display,omitempty"`
+	DisplayLabel          string `json:"displayLabel,omitempty"`
+	DisplayOrder          int    `json:"displayOrder,omitempty"`
+	DisplayPageNumber     int    `json:"displayPageNumber,omitempty"`
+	HideLabelWhenOpened   DSBool `json:"hideLabelWhenOpened,omitempty"`
+	InlineOuterStyle      string `json:"inlineOuterStyle,omitempty"`
+	LabelWhenOpened       string `json:"labelWhenOpened,omitempty"`
+	ScrollToTopWhenOpened DSBool `json:"scrollToTopWhenOpened,omitempty"`
+	TableStyle            string `json:"tableStyle,omitempty"`
+}
+
+// HtmlDefinitionList is the response for DocumentHtmlDefinitions and
+// EnvelopeHtmlDefinitions.
+type HtmlDefinitionList struct {
+	HtmlDefinitions []HtmlDefinitionOriginal `json:"htmlDefinitions,omitempty"`
+}
+
+// HtmlDefinitionOriginal is the html definition stored for a document.
+type HtmlDefinitionOriginal struct {
+	DocumentId     string                  `json:"documentId,omitempty"`
+	DocumentIdGuid string                  `json:"documentIdGuid,omitempty"`
+	HtmlDefinition *DocumentHtmlDefinition `json:"htmlDefinition,omitempty"`
 }
 
 // Matchbox describes the area used for template matching
@@ -229,12 +578,12 @@ type DocumentList struct {
 }
 
 type DocumentFieldList struct {
-	DocumentFields []CustomDocumentField `json:documentFields,omitempty"`
+	DocumentFields []CustomDocumentField `json:"documentFields,omitempty"`
 }
 
 type CustomDocumentField struct {
 	NmVal
-	ErrorDetails *ResponseError `json:errorDetails,omitempty"`
+	ErrorDetails *ResponseError `json:"errorDetails,omitempty"`
 }
 
 type EventNotification struct {
@@ -265,11 +614,19 @@ type RecipientEvent struct {
 	IncludeDocuments string `json:"includeDocuments,omitempty"`
 }
 
+// EmailSetting overrides the reply to address and adds bcc
+// addresses for the emails of an envelope.
+//
 // Documentation: https://www.docusign.com/p/RESTAPIGuide/Content/REST%20API%20References/Add%20Email%20Setting%20Overrides%20to%20an%20Envelope.htm
 type EmailSetting struct {
-	ReplyEmailAddressOverride string `json:"replyEmailAddressOverride,omitempty"`
-	ReplyEmailNameOverride    string `json:"replyEmailNameOverride,omitempty"`
-	BccEmailAddresses         string `json:"bccEmailAddresses,omitempty"`
+	ReplyEmailAddressOverride string     `json:"replyEmailAddressOverride,omitempty"`
+	ReplyEmailNameOverride    string     `json:"replyEmailNameOverride,omitempty"`
+	BccEmailAddresses         []BccEmail `json:"bccEmailAddresses,omitempty"`
+}
+
+// AddBcc appends email to the bcc addresses.
+func (e *EmailSetting) AddBcc(email string) {
+	e.BccEmailAddresses = append(e.BccEmailAddresses, BccEmail{Email: email})
 }
 
 type TemplateRole struct {
@@ -289,42 +646,167 @@ type EnvelopeList struct {
 	Envelopes []EnvelopeUris `json:"envelopes"`
 }
 type EnvelopeUris struct {
-	AllowReassign         string    `json:"allowReassign,omitempty"`
-	CertificateUri        string    `json:"certificateUri,omitempty"`
-	CreatedDateTime       time.Time `json:"createdDateTime,omitempty"`
-	CustomFieldsUri       string    `json:"customFieldsUri,omitempty"`
-	DocumentsCombinedUri  string    `json:"documentsCombinedUri,omitempty"`
-	DocumentsUri          string    `json:"documentsUri,omitempty"`
-	EmailBlurb            string    `json:"emailBlurb,omitempty"`
-	EmailSubject          string    `json:"emailSubject,omitempty"`
-	EnableWetSign         string    `json:"enableWetSign,omitempty"`
-	EnvelopeId            string    `json:"envelopeId,omitempty"`
-	EnvelopeUri           string    `json:"envelopeUri,omitempty"`
-	LastModifiedDateTime  time.Time `json:"lastModifiedDateTime,omitempty"`
-	NotificationUri       string    `json:"notificationUri,omitempty"`
-	PurgeState            string    `json:"purgeState,omitempty"`
-	RecipientsUri         string    `json:"recipientsUri,omitempty"`
-	Status                string    `json:"status,omitempty"`
-	StatusChangedDateTime time.Time `json:"statusChangedDateTime,omitempty"`
-	TemplatesUri          string    `json:"templatesUri,omitempty"`
+	AllowReassign         string         `json:"allowReassign,omitempty"`
+	CertificateUri        string         `json:"certificateUri,omitempty"`
+	CreatedDateTime       time.Time      `json:"createdDateTime,omitempty"`
+	CustomFieldsUri       string         `json:"customFieldsUri,omitempty"`
+	DocumentsCombinedUri  string         `json:"documentsCombinedUri,omitempty"`
+	DocumentsUri          string         `json:"documentsUri,omitempty"`
+	EmailBlurb            string         `json:"emailBlurb,omitempty"`
+	EmailSubject          string         `json:"emailSubject,omitempty"`
+	EnableWetSign         string         `json:"enableWetSign,omitempty"`
+	EnvelopeId            string         `json:"envelopeId,omitempty"`
+	EnvelopeUri           string         `json:"envelopeUri,omitempty"`
+	LastModifiedDateTime  time.Time      `json:"lastModifiedDateTime,omitempty"`
+	NotificationUri       string         `json:"notificationUri,omitempty"`
+	PurgeState            string         `json:"purgeState,omitempty"`
+	RecipientsUri         string         `json:"recipientsUri,omitempty"`
+	Status                EnvelopeStatus `json:"status,omitempty"`
+	StatusChangedDateTime time.Time      `json:"statusChangedDateTime,omitempty"`
+	TemplatesUri          string         `json:"templatesUri,omitempty"`
 }
 
 type AuditEventList struct {
 	AuditEvents []AuditEvent `json:"auditEvents,omitempty"`
 }
 
+// Filter returns the events whose Action field equals action.
+func (l AuditEventList) Filter(action string) []AuditEvent {
+	var events []AuditEvent
+	for _, e := range l.AuditEvents {
+		if e.Field("Action") == action {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
 type AuditEvent struct {
 	EventFields []NmVal `json:"eventFields,omitempty"`
 }
 
+// Field returns the value of the named event field, or an empty string
+// if the field does not exist.
+func (e AuditEvent) Field(name string) string {
+	for _, nv := range e.EventFields {
+		if nv.Name == name {
+			return nv.Value
+		}
+	}
+	return ""
+}
+
+// Time parses the event's logTime field.  Values without a time zone
+// are treated as UTC.
+func (e AuditEvent) Time() (time.Time, error) {
+	v := e.Field("logTime")
+	tm, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		if tm, err = time.Parse("2006-01-02T15:04:05.999999999", v); err != nil {
+			return tm, fmt.Errorf("docusign: invalid audit event logTime %q", v)
+		}
+	}
+	return tm, nil
+}
+
+// Certificate is the structured certificate of completion returned by
+// CertificateOfCompletion.
+type Certificate struct {
+	EnvelopeId        string
+	EmailSubject      string
+	Status            EnvelopeStatus
+	CreatedDateTime   DSTime
+	SentDateTime      DSTime
+	CompletedDateTime DSTime
+	Recipients        []CertificateRecipient
+	// Events are the envelope's audit events in the order returned.
+	Events []AuditEvent
+}
+
+// CertificateRecipient records a recipient's actions.  Audit events are
+// matched to the recipient by name, so recipients sharing a name share
+// their events.
+type CertificateRecipient struct {
+	RecipientSummary
+	// ClientIPAddress is the address of the recipient's signing event or,
+	// if the recipient has not signed, of their last event.
+	ClientIPAddress string
+	// AuthenticationMethods lists the access code and id check
+	// configuration required of the recipient.
+	AuthenticationMethods []string
+	Events                []AuditEvent
+}
+
+// newCertificate builds a Certificate from the results of EnvelopeGet,
+// Recipients and EnvelopeAuditEvents.
+func newCertificate(env *Envelope, rl *RecipientList, events *AuditEventList) *Certificate {
+	cert := &Certificate{
+		EnvelopeId:        env.EnvelopeId,
+		EmailSubject:      env.EmailSubject,
+		Status:            env.Status,
+		CreatedDateTime:   env.CreatedDateTime,
+		SentDateTime:      env.SentDateTime,
+		CompletedDateTime: env.CompletedDateTime,
+		Events:            events.AuditEvents,
+	}
+	auth := make(map[string][]string)
+	set := func(r Recipient) {
+		var methods []string
+		if r.AccessCode != "" || bool(r.TemplateAccessCodeRequired) {
+			methods = append(methods, "AccessCode")
+		}
+		if r.IdCheckConfigurationName != "" {
+			methods = append(methods, r.IdCheckConfigurationName)
+		}
+		auth[r.RecipientId] = methods
+	}
+	for _, x := range rl.Agents {
+		set(x.Recipient)
+	}
+	for _, x := range rl.CarbonCopies {
+		set(x.Recipient)
+	}
+	for _, x := range rl.CertifiedDeliveries {
+		set(x.Recipient)
+	}
+	for _, x := range rl.Editors {
+		set(x.Recipient)
+	}
+	for _, x := range rl.InPersonSigners {
+		set(x.Recipient)
+	}
+	for _, x := range rl.Intermediaries {
+		set(x.Recipient)
+	}
+	for _, x := range rl.Signers {
+		set(x.Recipient)
+	}
+	for _, sum := range rl.AllRecipients() {
+		cr := CertificateRecipient{RecipientSummary: sum, AuthenticationMethods: auth[sum.RecipientId]}
+		var signed bool
+		for _, e := range events.AuditEvents {
+			if sum.Name == "" || e.Field("UserName") != sum.Name {
+				continue
+			}
+			cr.Events = append(cr.Events, e)
+			if !signed {
+				cr.ClientIPAddress = e.Field("ClientIPAddress")
+				signed = e.Field("Action") == "Signed"
+			}
+		}
+		cert.Recipients = append(cert.Recipients, cr)
+	}
+	return cert
+}
+
 type DocumentAsset struct {
 	Name         string         `json:"name,omitempty"`
 	Type         string         `json:"type,omitempty"`
 	DocumentId   string         `json:"documentId,omitempty"`
 	Order        string         `json:"order,omitempty"`
 	Pages        string         `json:"pages,omitempty"`
-	Uri          string         `json:"uri:omitempty"`
-	ErrorDetails *ResponseError `json:errorDetails,omitempty"`
+	Uri          string         `json:"uri,omitempty"`
+	ErrorDetails *ResponseError `json:"errorDetails,omitempty"`
 }
 
 type DocumentAssetList struct {
@@ -352,10 +834,10 @@ type LoginAccount struct {
 }
 
 type EnvelopeResponse struct {
-	EnvelopeId     string    `json:"envelopeId,omitempty"`
-	Status         string    `json:"status,omitempty"`
-	StatusDateTime time.Time `json:"statusDateTime,omitempty"`
-	Uri            string    `json:"uri,omitempty"`
+	EnvelopeId     string         `json:"envelopeId,omitempty"`
+	Status         EnvelopeStatus `json:"status,omitempty"`
+	StatusDateTime time.Time      `json:"statusDateTime,omitempty"`
+	Uri            string         `json:"uri,omitempty"`
 }
 
 // Return structure for GetEnvelopeTemplate call
@@ -425,3 +907,584 @@ type TemplateOwner struct {
 	UserType   string `json:"userType,omitempty"`
 	UserStatus string `json:"userStatus,omitempty"`
 }
+
+// LockRequest is the payload for creating or updating an envelope lock.
+// LockType must be "edit".
+type LockRequest struct {
+	LockDurationInSeconds string `json:"lockDurationInSeconds,omitempty"`
+	LockedByApp           string `json:"lockedByApp,omitempty"`
+	LockType              string `json:"lockType,omitempty"`
+	TemplatePassword      string `json:"templatePassword,omitempty"`
+	UseScratchPadEnvelope DSBool `json:"useScratchPadEnvelope,omitempty"`
+}
+
+// LockInfo describes an envelope lock.  LockToken must be passed
+// to update or delete the lock.
+type LockInfo struct {
+	LockDurationInSeconds string              `json:"lockDurationInSeconds,omitempty"`
+	LockedByApp           string              `json:"lockedByApp,omitempty"`
+	LockedByUser          *TemplateModifiedBy `json:"lockedByUser,omitempty"`
+	LockedUntilDateTime   string              `json:"lockedUntilDateTime,omitempty"`
+	LockToken             string              `json:"lockToken,omitempty"`
+	LockType              string              `json:"lockType,omitempty"`
+	UseScratchPadEnvelope DSBool              `json:"useScratchPadEnvelope,omitempty"`
+	ErrorDetails          *ResponseError      `json:"errorDetails,omitempty"`
+}
+
+// CorrectionRequest contains the changes made by EnvelopeCorrect.
+type CorrectionRequest struct {
+	// Recipients contains modified recipients identified by RecipientId.
+	Recipients *RecipientList
+	// Tabs contains modified tabs keyed by recipient id.  Tabs are
+	// identified by TabId.
+	Tabs map[string]*Tabs
+	// LockDurationInSeconds is the duration of the lock held while
+	// applying changes.  The DocuSign default is used when empty.
+	LockDurationInSeconds string
+}
+
+// SignatureProviderList is the response for SignatureProviders.
+type SignatureProviderList struct {
+	SignatureProviders []SignatureProvider `json:"signatureProviders,omitempty"`
+}
+
+// SignatureProvider describes a provider of electronic seals or
+// standards based signatures.
+type SignatureProvider struct {
+	IsRequired                       DSBool                             `json:"isRequired,omitempty"`
+	Priority                         string                             `json:"priority,omitempty"`
+	SignatureProviderDisplayName     string                             `json:"signatureProviderDisplayName,omitempty"`
+	SignatureProviderId              string                             `json:"signatureProviderId,omitempty"`
+	SignatureProviderName            string                             `json:"signatureProviderName,omitempty"`
+	SignatureProviderOptionsMetadata []SignatureProviderOptionMetadata  `json:"signatureProviderOptionsMetadata,omitempty"`
+	SignatureProviderRequiredOptions []SignatureProviderRequiredOptions `json:"signatureProviderRequiredOptions,omitempty"`
+}
+
+// SignatureProviderOptionMetadata describes an option that may be set
+// in a RecipientSignatureProvider's SignatureProviderOptions.
+type SignatureProviderOptionMetadata struct {
+	SignatureProviderOptionId   string `json:"signatureProviderOptionId,omitempty"`
+	SignatureProviderOptionName string `json:"signatureProviderOptionName,omitempty"`
+	SignatureProviderDataSource string `json:"signatureProviderDataSource,omitempty"`
+	SignatureProviderDataType   string `json:"signatureProviderDataType,omitempty"`
+}
+
+// SignatureProviderRequiredOptions lists the option ids required
+// for a signer type.
+type SignatureProviderRequiredOptions struct {
+	RequiredSignatureProviderOptionIds []string `json:"requiredSignatureProviderOptionIds,omitempty"`
+	SignerType                         string   `json:"signerType,omitempty"`
+}
+
+// AccountSettings contains the account wide settings returned by
+// AccountSettings.
+type AccountSettings struct {
+	AccountSettings NmVals `json:"accountSettings,omitempty"`
+}
+
+// BrandList is the response for BrandList and BrandCreate.
+type BrandList struct {
+	Brands                  []Brand `json:"brands,omitempty"`
+	RecipientBrandIdDefault string  `json:"recipientBrandIdDefault,omitempty"`
+	SenderBrandIdDefault    string  `json:"senderBrandIdDefault,omitempty"`
+}
+
+// Brand describes an account brand.  Use BrandId as an Envelope's BrandId.
+type Brand struct {
+	BrandId                 string             `json:"brandId,omitempty"`
+	BrandName               string             `json:"brandName,omitempty"`
+	BrandCompany            string             `json:"brandCompany,omitempty"`
+	BrandLanguages          []string           `json:"brandLanguages,omitempty"`
+	DefaultBrandLanguage    string             `json:"defaultBrandLanguage,omitempty"`
+	IsOverridingCompanyName DSBool             `json:"isOverridingCompanyName,omitempty"`
+	IsSendingDefault        DSBool             `json:"isSendingDefault,omitempty"`
+	IsSigningDefault        DSBool             `json:"isSigningDefault,omitempty"`
+	Colors                  []NmVal            `json:"colors,omitempty"`
+	Logos                   *BrandLogos        `json:"logos,omitempty"`
+	Resources               *BrandResourceUrls `json:"resources,omitempty"`
+	ErrorDetails            *ResponseError     `json:"errorDetails,omitempty"`
+}
+
+// BrandLogos contains the uris of a brand's logos.
+type BrandLogos struct {
+	Primary   string `json:"primary,omitempty"`
+	Secondary string `json:"secondary,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// BrandResourceUrls contains the uris of a brand's localized resource files.
+type BrandResourceUrls struct {
+	Email          string `json:"email,omitempty"`
+	Sending        string `json:"sending,omitempty"`
+	Signing        string `json:"signing,omitempty"`
+	SigningCaptive string `json:"signingCaptive,omitempty"`
+}
+
+// BrandResourceList is the response for BrandResourceList.
+type BrandResourceList struct {
+	ResourcesContentTypes []BrandResource `json:"resourcesContentTypes,omitempty"`
+}
+
+// BrandResource describes a brand resource file.
+type BrandResource struct {
+	ResourcesContentType string   `json:"resourcesContentType,omitempty"`
+	ResourcesContentUri  string   `json:"resourcesContentUri,omitempty"`
+	ModifiedBy           string   `json:"modifiedBy,omitempty"`
+	ModifiedDate         string   `json:"modifiedDate,omitempty"`
+	ModifiedTemplates    []string `json:"modifiedTemplates,omitempty"`
+}
+
+// TemplateResponse is returned by TemplateCreate.
+type TemplateResponse struct {
+	TemplateId string `json:"templateId,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Uri        string `json:"uri,omitempty"`
+}
+
+// TemplateUpdateSummary is returned by TemplateUpdate.
+type TemplateUpdateSummary struct {
+	EnvelopeId             string         `json:"envelopeId,omitempty"`
+	PurgeState             string         `json:"purgeState,omitempty"`
+	LockInformation        *LockInfo      `json:"lockInformation,omitempty"`
+	RecipientUpdateResults []Recipient    `json:"recipientUpdateResults,omitempty"`
+	ErrorDetails           *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// TemplateSharedItems lists the groups used by TemplateShare
+// and TemplateShareRemove.
+type TemplateSharedItems struct {
+	Groups []Group `json:"groups,omitempty"`
+}
+
+// Group is an account group.
+type Group struct {
+	GroupId             string         `json:"groupId,omitempty"`
+	GroupName           string         `json:"groupName,omitempty"`
+	GroupType           string         `json:"groupType,omitempty"`
+	PermissionProfileId string         `json:"permissionProfileId,omitempty"`
+	ErrorDetails        *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// AccountSharedAccess is the response of the shared_access call.
+type AccountSharedAccess struct {
+	SharedAccess  []MemberSharedItems `json:"sharedAccess,omitempty"`
+	ResultSetSize string              `json:"resultSetSize,omitempty"`
+	TotalSetSize  string              `json:"totalSetSize,omitempty"`
+	StartPosition string              `json:"startPosition,omitempty"`
+	EndPosition   string              `json:"endPosition,omitempty"`
+	ErrorDetails  *ResponseError      `json:"errorDetails,omitempty"`
+}
+
+// MemberSharedItems contains the templates shared by a user.
+type MemberSharedItems struct {
+	User         *UserInfo            `json:"user,omitempty"`
+	Templates    []TemplateSharedItem `json:"templates,omitempty"`
+	ErrorDetails *ResponseError       `json:"errorDetails,omitempty"`
+}
+
+// TemplateSharedItem lists the groups and users sharing a template.
+type TemplateSharedItem struct {
+	TemplateId   string                  `json:"templateId,omitempty"`
+	TemplateName string                  `json:"templateName,omitempty"`
+	Shared       string                  `json:"shared,omitempty"`
+	Owner        *UserInfo               `json:"owner,omitempty"`
+	SharedGroups []MemberGroupSharedItem `json:"sharedGroups,omitempty"`
+	SharedUsers  []UserSharedItem        `json:"sharedUsers,omitempty"`
+	ErrorDetails *ResponseError          `json:"errorDetails,omitempty"`
+}
+
+// MemberGroupSharedItem is a group share entry.
+type MemberGroupSharedItem struct {
+	Group        *Group         `json:"group,omitempty"`
+	Shared       string         `json:"shared,omitempty"`
+	ErrorDetails *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// UserSharedItem is a user share entry.
+type UserSharedItem struct {
+	User         *UserInfo      `json:"user,omitempty"`
+	Shared       string         `json:"shared,omitempty"`
+	ErrorDetails *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// UserInfoList is the response for UserList.
+type UserInfoList struct {
+	Users         []UserInfo `json:"users,omitempty"`
+	ResultSetSize string     `json:"resultSetSize,omitempty"`
+	TotalSetSize  string     `json:"totalSetSize,omitempty"`
+	StartPosition string     `json:"startPosition,omitempty"`
+	EndPosition   string     `json:"endPosition,omitempty"`
+	NextUri       string     `json:"nextUri,omitempty"`
+	PreviousUri   string     `json:"previousUri,omitempty"`
+}
+
+// UserInfo describes an account user.
+type UserInfo struct {
+	UserId                string         `json:"userId,omitempty"`
+	UserName              string         `json:"userName,omitempty"`
+	FirstName             string         `json:"firstName,omitempty"`
+	LastName              string         `json:"lastName,omitempty"`
+	Email                 string         `json:"email,omitempty"`
+	Password              string         `json:"password,omitempty"`
+	UserStatus            string         `json:"userStatus,omitempty"`
+	UserType              string         `json:"userType,omitempty"`
+	IsAdmin               DSBool         `json:"isAdmin,omitempty"`
+	PermissionProfileId   string         `json:"permissionProfileId,omitempty"`
+	PermissionProfileName string         `json:"permissionProfileName,omitempty"`
+	CreatedDateTime       string         `json:"createdDateTime,omitempty"`
+	Uri                   string         `json:"uri,omitempty"`
+	ErrorDetails          *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// EnvelopeTransferRuleList is the response for EnvelopeTransferRules and
+// EnvelopeTransferRuleCreate.
+type EnvelopeTransferRuleList struct {
+	EnvelopeTransferRules []EnvelopeTransferRule `json:"envelopeTransferRules,omitempty"`
+	ResultSetSize         string                 `json:"resultSetSize,omitempty"`
+	TotalSetSize          string                 `json:"totalSetSize,omitempty"`
+	StartPosition         string                 `json:"startPosition,omitempty"`
+	EndPosition           string                 `json:"endPosition,omitempty"`
+	NextUri               string                 `json:"nextUri,omitempty"`
+	PreviousUri           string                 `json:"previousUri,omitempty"`
+}
+
+// EnvelopeTransferRule transfers ownership of envelopes from a user or group
+// to another user.  Enabled and CarbonCopyOriginalOwner are pointers so that
+// false may be sent in an update.
+type EnvelopeTransferRule struct {
+	EnvelopeTransferRuleId  string         `json:"envelopeTransferRuleId,omitempty"`
+	CarbonCopyOriginalOwner *DSBool        `json:"carbonCopyOriginalOwner,omitempty"`
+	Enabled                 *DSBool        `json:"enabled,omitempty"`
+	EventType               string         `json:"eventType,omitempty"`
+	FromGroup               *Group         `json:"fromGroup,omitempty"`
+	FromUser                *UserInfo      `json:"fromUser,omitempty"`
+	ToUser                  *UserInfo      `json:"toUser,omitempty"`
+	ToFolder                *Folder        `json:"toFolder,omitempty"`
+	ModifiedDate            string         `json:"modifiedDate,omitempty"`
+	ModifiedUser            *UserInfo      `json:"modifiedUser,omitempty"`
+	ErrorDetails            *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// EnvelopeTransferRuleRequest is the payload for EnvelopeTransferRuleCreate.
+type EnvelopeTransferRuleRequest struct {
+	CarbonCopyOriginalOwner *DSBool    `json:"carbonCopyOriginalOwner,omitempty"`
+	Enabled                 *DSBool    `json:"enabled,omitempty"`
+	EventType               string     `json:"eventType,omitempty"`
+	FromGroups              []Group    `json:"fromGroups,omitempty"`
+	FromUsers               []UserInfo `json:"fromUsers,omitempty"`
+	ToUser                  *UserInfo  `json:"toUser,omitempty"`
+	ToFolder                *Folder    `json:"toFolder,omitempty"`
+}
+
+// NewUsersDefinition is the payload for UserCreate.
+type NewUsersDefinition struct {
+	NewUsers []UserInfo `json:"newUsers,omitempty"`
+}
+
+// NewUsersSummary is the response for UserCreate.
+type NewUsersSummary struct {
+	NewUsers []UserInfo `json:"newUsers,omitempty"`
+}
+
+// PageList is the response for DocumentPages.
+type PageList struct {
+	Pages []Page `json:"pages,omitempty"`
+}
+
+// Page describes a document page.
+type Page struct {
+	PageId     string `json:"pageId,omitempty"`
+	Sequence   string `json:"sequence,omitempty"`
+	Height     string `json:"height,omitempty"`
+	Width      string `json:"width,omitempty"`
+	Dpi        string `json:"dpi,omitempty"`
+	ImageBytes string `json:"imageBytes,omitempty"`
+}
+
+// PowerFormList is the response for PowerFormList.
+type PowerFormList struct {
+	PowerForms    []PowerForm `json:"powerForms,omitempty"`
+	ResultSetSize string      `json:"resultSetSize,omitempty"`
+	TotalSetSize  string      `json:"totalSetSize,omitempty"`
+	StartPosition string      `json:"startPosition,omitempty"`
+	EndPosition   string      `json:"endPosition,omitempty"`
+	NextUri       string      `json:"nextUri,omitempty"`
+	PreviousUri   string      `json:"previousUri,omitempty"`
+}
+
+// PowerForm describes a self-service form created from a template.
+// SigningMode is either "email" or "direct".
+type PowerForm struct {
+	PowerFormId     string               `json:"powerFormId,omitempty"`
+	Name            string               `json:"name,omitempty"`
+	TemplateId      string               `json:"templateId,omitempty"`
+	TemplateName    string               `json:"templateName,omitempty"`
+	PowerFormUrl    string               `json:"powerFormUrl,omitempty"`
+	EmailSubject    string               `json:"emailSubject,omitempty"`
+	EmailBody       string               `json:"emailBody,omitempty"`
+	SigningMode     string               `json:"signingMode,omitempty"`
+	Instructions    string               `json:"instructions,omitempty"`
+	IsActive        DSBool               `json:"isActive,omitempty"`
+	MaxUseEnabled   DSBool               `json:"maxUseEnabled,omitempty"`
+	TimesUsed       string               `json:"timesUsed,omitempty"`
+	UsesRemaining   string               `json:"usesRemaining,omitempty"`
+	SenderName      string               `json:"senderName,omitempty"`
+	SenderUserId    string               `json:"senderUserId,omitempty"`
+	CreatedDateTime string               `json:"createdDateTime,omitempty"`
+	Recipients      []PowerFormRecipient `json:"recipients,omitempty"`
+	Uri             string               `json:"uri,omitempty"`
+	ErrorDetails    *ResponseError       `json:"errorDetails,omitempty"`
+}
+
+// PowerFormRecipient maps a template role to a PowerForm recipient.
+type PowerFormRecipient struct {
+	RoleName                 string `json:"roleName,omitempty"`
+	RecipientType            string `json:"recipientType,omitempty"`
+	RecipientId              string `json:"recipientId,omitempty"`
+	RoutingOrder             string `json:"routingOrder,omitempty"`
+	Name                     string `json:"name,omitempty"`
+	Email                    string `json:"email,omitempty"`
+	AccessCode               string `json:"accessCode,omitempty"`
+	EmailLocked              DSBool `json:"emailLocked,omitempty"`
+	UserNameLocked           DSBool `json:"userNameLocked,omitempty"`
+	TemplateRequiresIdLookup DSBool `json:"templateRequiresIdLookup,omitempty"`
+	IdCheckConfigurationName string `json:"idCheckConfigurationName,omitempty"`
+}
+
+// BillingPlanInfo is the response for BillingPlan.
+type BillingPlanInfo struct {
+	BillingPlan    *BillingPlan  `json:"billingPlan,omitempty"`
+	PaymentMethod  string        `json:"paymentMethod,omitempty"`
+	SuccessorPlans []BillingPlan `json:"successorPlans,omitempty"`
+}
+
+// BillingPlan describes an account's plan.  Envelope allowances are
+// reported per feature set.
+type BillingPlan struct {
+	PlanId             string           `json:"planId,omitempty"`
+	PlanName           string           `json:"planName,omitempty"`
+	PlanClassification string           `json:"planClassification,omitempty"`
+	PaymentCycle       string           `json:"paymentCycle,omitempty"`
+	PaymentMethod      string           `json:"paymentMethod,omitempty"`
+	CurrencyCode       string           `json:"currencyCode,omitempty"`
+	IncludedSeats      string           `json:"includedSeats,omitempty"`
+	IncrementalSeats   string           `json:"incrementalSeats,omitempty"`
+	PerSeatPrice       string           `json:"perSeatPrice,omitempty"`
+	RenewalStatus      string           `json:"renewalStatus,omitempty"`
+	PlanFeatureSets    []PlanFeatureSet `json:"planFeatureSets,omitempty"`
+}
+
+// PlanFeatureSet describes the fees and envelope allowance of a feature set.
+type PlanFeatureSet struct {
+	FeatureSetId      string `json:"featureSetId,omitempty"`
+	Name              string `json:"name,omitempty"`
+	IsActive          DSBool `json:"isActive,omitempty"`
+	IsEnabled         DSBool `json:"isEnabled,omitempty"`
+	CurrencyCode      string `json:"currencyCode,omitempty"`
+	EnvelopeFee       string `json:"envelopeFee,omitempty"`
+	FixedFee          string `json:"fixedFee,omitempty"`
+	SeatFee           string `json:"seatFee,omitempty"`
+	IncludedEnvelopes string `json:"includedEnvelopes,omitempty"`
+}
+
+// BillingInvoiceList is the response for BillingInvoices.
+type BillingInvoiceList struct {
+	BillingInvoices []BillingInvoice `json:"billingInvoices,omitempty"`
+	NextUri         string           `json:"nextUri,omitempty"`
+	PreviousUri     string           `json:"previousUri,omitempty"`
+}
+
+// BillingInvoice describes an account invoice.
+type BillingInvoice struct {
+	InvoiceId        string `json:"invoiceId,omitempty"`
+	InvoiceNumber    string `json:"invoiceNumber,omitempty"`
+	InvoiceUri       string `json:"invoiceUri,omitempty"`
+	Amount           string `json:"amount,omitempty"`
+	Balance          string `json:"balance,omitempty"`
+	DueDate          string `json:"dueDate,omitempty"`
+	TaxableAmount    string `json:"taxableAmount,omitempty"`
+	NonTaxableAmount string `json:"nonTaxableAmount,omitempty"`
+	PdfAvailable     DSBool `json:"pdfAvailable,omitempty"`
+}
+
+// CommentsList is the response for EnvelopeComments.
+type CommentsList struct {
+	Comments       []Comment `json:"comments,omitempty"`
+	StartTimetoken string    `json:"startTimetoken,omitempty"`
+	EndTimetoken   string    `json:"endTimetoken,omitempty"`
+}
+
+// Comment is a single comment.  Comments with the same ThreadId belong
+// to one thread started by ThreadOriginatorId.
+type Comment struct {
+	Id                 string   `json:"id,omitempty"`
+	EnvelopeId         string   `json:"envelopeId,omitempty"`
+	ThreadId           string   `json:"threadId,omitempty"`
+	ThreadOriginatorId string   `json:"threadOriginatorId,omitempty"`
+	Subject            string   `json:"subject,omitempty"`
+	Text               string   `json:"text,omitempty"`
+	SentByUserId       string   `json:"sentByUserId,omitempty"`
+	SentByFullName     string   `json:"sentByFullName,omitempty"`
+	SentByEmail        string   `json:"sentByEmail,omitempty"`
+	Timestamp          string   `json:"timestamp,omitempty"`
+	Mentions           []string `json:"mentions,omitempty"`
+	VisibleTo          []string `json:"visibleTo,omitempty"`
+	Read               DSBool   `json:"read,omitempty"`
+}
+
+// PaymentList is the response for EnvelopePayments.
+type PaymentList struct {
+	Payments []Payment `json:"payments,omitempty"`
+}
+
+// Payment summarizes the payment collected by a recipient's payment tab.
+type Payment struct {
+	RecipientId      string          `json:"recipientId,omitempty"`
+	TabLabel         string          `json:"tabLabel,omitempty"`
+	PaymentId        string          `json:"paymentId,omitempty"`
+	Status           string          `json:"status,omitempty"`
+	Amount           string          `json:"amount,omitempty"`
+	Currency         string          `json:"currency,omitempty"`
+	GatewayAccountId string          `json:"gatewayAccountId,omitempty"`
+	Details          *PaymentDetails `json:"details,omitempty"`
+}
+
+// ConsumerDisclosure is the ESIGN consumer disclosure shown to signers.
+// EsignText contains the disclosure text (html).
+type ConsumerDisclosure struct {
+	AccountEsignId                     string `json:"accountEsignId,omitempty"`
+	AllowCDWithdraw                    DSBool `json:"allowCDWithdraw,omitempty"`
+	ChangeEmail                        string `json:"changeEmail,omitempty"`
+	CompanyName                        string `json:"companyName,omitempty"`
+	CompanyPhone                       string `json:"companyPhone,omitempty"`
+	CopyCostPerPage                    string `json:"copyCostPerPage,omitempty"`
+	CopyFeeCollectionMethod            string `json:"copyFeeCollectionMethod,omitempty"`
+	CopyRequestEmail                   string `json:"copyRequestEmail,omitempty"`
+	Custom                             DSBool `json:"custom,omitempty"`
+	EnableEsign                        DSBool `json:"enableEsign,omitempty"`
+	EsignAgreement                     string `json:"esignAgreement,omitempty"`
+	EsignText                          string `json:"esignText,omitempty"`
+	LanguageCode                       string `json:"languageCode,omitempty"`
+	MustAgreeToEsign                   DSBool `json:"mustAgreeToEsign,omitempty"`
+	PdfId                              string `json:"pdfId,omitempty"`
+	UseBrand                           DSBool `json:"useBrand,omitempty"`
+	UseConsumerDisclosureWithinAccount DSBool `json:"useConsumerDisclosureWithinAccount,omitempty"`
+	WithdrawEmail                      string `json:"withdrawEmail,omitempty"`
+}
+
+// EnvelopeFormData is the response for EnvelopeFormData.
+type EnvelopeFormData struct {
+	EnvelopeId        string              `json:"envelopeId,omitempty"`
+	EmailSubject      string              `json:"emailSubject,omitempty"`
+	Status            EnvelopeStatus      `json:"status,omitempty"`
+	SentDateTime      DSTime              `json:"sentDateTime,omitempty"`
+	FormData          []FormDataItem      `json:"formData,omitempty"`
+	RecipientFormData []RecipientFormData `json:"recipientFormData,omitempty"`
+}
+
+// FormDataItem is the value of a single form field.
+type FormDataItem struct {
+	Name              string         `json:"name,omitempty"`
+	Value             string         `json:"value,omitempty"`
+	OriginalValue     string         `json:"originalValue,omitempty"`
+	ListSelectedValue string         `json:"listSelectedValue,omitempty"`
+	ErrorDetails      *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// RecipientFormData contains the form field values entered by a recipient.
+type RecipientFormData struct {
+	RecipientId   string         `json:"recipientId,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Email         string         `json:"email,omitempty"`
+	FormData      []FormDataItem `json:"formData,omitempty"`
+	SentTime      DSTime         `json:"SentTime,omitempty"`
+	DeliveredTime DSTime         `json:"DeliveredTime,omitempty"`
+	SignedTime    DSTime         `json:"SignedTime,omitempty"`
+	DeclinedTime  DSTime         `json:"DeclinedTime,omitempty"`
+}
+
+// Values returns the name and value of each of the recipient's fields.
+func (r RecipientFormData) Values() NmVals {
+	vals := make([]NmVal, len(r.FormData))
+	for i, v := range r.FormData {
+		vals[i] = NmVal{Name: v.Name, Value: v.Value}
+	}
+	return vals
+}
+
+// SigningGroupList is used to list and create signing groups.
+type SigningGroupList struct {
+	Groups []SigningGroup `json:"groups,omitempty"`
+}
+
+// SigningGroup is a group of users, any of whom may sign for the group.
+// Route an envelope to the group by setting a recipient's SigningGroupId.
+type SigningGroup struct {
+	SigningGroupId string             `json:"signingGroupId,omitempty"`
+	GroupName      string             `json:"groupName,omitempty"`
+	GroupType      string             `json:"groupType,omitempty"`
+	GroupEmail     string             `json:"groupEmail,omitempty"`
+	Created        string             `json:"created,omitempty"`
+	CreatedBy      string             `json:"createdBy,omitempty"`
+	Modified       string             `json:"modified,omitempty"`
+	ModifiedBy     string             `json:"modifiedBy,omitempty"`
+	Users          []SigningGroupUser `json:"users,omitempty"`
+	ErrorDetails   *ResponseError     `json:"errorDetails,omitempty"`
+}
+
+// SigningGroupUser is a member of a signing group.
+type SigningGroupUser struct {
+	UserName string `json:"userName,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// TemplateApply lists the templates to apply to envelope documents.
+type TemplateApply struct {
+	DocumentTemplates []DocumentTemplate `json:"documentTemplates,omitempty"`
+}
+
+// DocumentTemplate applies a template to a page range of a document.
+type DocumentTemplate struct {
+	DocumentId        string         `json:"documentId,omitempty"`
+	TemplateId        string         `json:"templateId,omitempty"`
+	DocumentStartPage string         `json:"documentStartPage,omitempty"`
+	DocumentEndPage   string         `json:"documentEndPage,omitempty"`
+	ErrorDetails      *ResponseError `json:"errorDetails,omitempty"`
+}
+
+// WorkspaceList is the response for WorkspaceList.
+type WorkspaceList struct {
+	Workspaces    []Workspace `json:"workspaces,omitempty"`
+	ResultSetSize string      `json:"resultSetSize,omitempty"`
+	TotalSetSize  string      `json:"totalSetSize,omitempty"`
+	StartPosition string      `json:"startPosition,omitempty"`
+	EndPosition   string      `json:"endPosition,omitempty"`
+}
+
+// Workspace describes a collaborative workspace.
+type Workspace struct {
+	WorkspaceId          string `json:"workspaceId,omitempty"`
+	WorkspaceName        string `json:"workspaceName,omitempty"`
+	WorkspaceDescription string `json:"workspaceDescription,omitempty"`
+	WorkspaceBaseUrl     string `json:"workspaceBaseUrl,omitempty"`
+	WorkspaceUri         string `json:"workspaceUri,omitempty"`
+	Status               string `json:"status,omitempty"`
+	Created              string `json:"created,omitempty"`
+	LastModified         string `json:"lastModified,omitempty"`
+}
+
+// WorkspaceItem describes a file or folder in a workspace.
+type WorkspaceItem struct {
+	Id             string         `json:"id,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	Type           string         `json:"type,omitempty"`
+	Uri            string         `json:"uri,omitempty"`
+	ContentType    string         `json:"contentType,omitempty"`
+	SizeBytes      string         `json:"sizeBytes,omitempty"`
+	PageCount      string         `json:"pageCount,omitempty"`
+	ParentFolderId string         `json:"parentFolderId,omitempty"`
+	IsPublic       DSBool         `json:"isPublic,omitempty"`
+	Created        string         `json:"created,omitempty"`
+	LastModified   string         `json:"lastModified,omitempty"`
+	ErrorDetails   *ResponseError `json:"errorDetails,omitempty"`
+}
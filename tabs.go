@@ -5,7 +5,10 @@
 
 package docusign
 
-import "strings"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // Tabs describes the data tabs for a recipient
 type Tabs struct {
@@ -17,6 +20,7 @@ type Tabs struct {
 	DeclineTabs          []DeclineTab          `json:"declineTabs,omitempty"`
 	EmailTabs            []EmailTab            `json:"emailTabs,omitempty"`
 	EnvelopeIdTabs       []EnvelopeIdTab       `json:"envelopeIdTabs,omitempty"`
+	FormulaTabs          []FormulaTab          `json:"formulaTabs,omitempty"`
 	FullNameTabs         []FullNameTab         `json:"fullNameTabs,omitempty"`
 	InitialHereTabs      []InitialHereTab      `json:"initialHereTabs,omitempty"`
 	ListTabs             []ListTab             `json:"listTabs,omitempty"`
@@ -31,7 +35,40 @@ type Tabs struct {
 	ZipTabs              []ZipTab              `json:"zipTabs,omitempty"`
 }
 
-func (t Tabs) Values() []NmVal {
+// cloneFor returns a copy of the tabs assigned to recipId with tab ids and
+// errors removed so they may be added as new tabs.  EnvelopeIdTabs are
+// placed automatically and are not copied.
+func (t Tabs) cloneFor(recipId string) (*Tabs, error) {
+	t.EnvelopeIdTabs = nil
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var lists map[string][]map[string]interface{}
+	if err = json.Unmarshal(b, &lists); err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		for _, tab := range list {
+			delete(tab, "tabId")
+			delete(tab, "errorDetails")
+			tab["recipientID"] = recipId
+			radios, _ := tab["radios"].([]interface{})
+			for _, r := range radios {
+				if radio, ok := r.(map[string]interface{}); ok {
+					delete(radio, "tabId")
+				}
+			}
+		}
+	}
+	if b, err = json.Marshal(lists); err != nil {
+		return nil, err
+	}
+	var clone Tabs
+	return &clone, json.Unmarshal(b, &clone)
+}
+
+func (t Tabs) Values() NmVals {
 	vals := make([]NmVal, 0, len(t.CheckboxTabs)+len(t.CompanyTabs)+len(t.DateTabs)+len(t.EmailTabs)+len(t.ListTabs)+len(t.NoteTabs)+len(t.NumberTabs)+len(t.RadioGroupTabs)+len(t.SsnTabs)+len(t.TextTabs)+len(t.TitleTabs)+len(t.ZipTabs))
 	for _, v := range t.CheckboxTabs {
 		vals = append(vals, v.NmVal())
@@ -72,6 +109,143 @@ func (t Tabs) Values() []NmVal {
 	return vals
 }
 
+// FirstError returns the ErrorDetails of the first tab reporting
+// an error, or nil if all tabs succeeded.  Use after batch calls
+// such as RecipientTabsAdd and RecipientTabsModify.  A nil Tabs has
+// no error.
+func (t *Tabs) FirstError() error {
+	if t == nil {
+		return nil
+	}
+	for _, v := range t.ApproveTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.CheckboxTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.CompanyTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.DateSignedTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.DateTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.DeclineTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.EmailTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.FormulaTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.EnvelopeIdTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.FullNameTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.InitialHereTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.ListTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.NoteTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.NumberTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.SignHereTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.SignerAttachmentTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.SsnTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.TextTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.TitleTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	for _, v := range t.ZipTabs {
+		if v.ErrorDetails != nil {
+			return v.ErrorDetails
+		}
+	}
+	return nil
+}
+
+// AnchorPos returns a BasePosTab placing a tab at each occurrence of anchor.
+func AnchorPos(anchor string) BasePosTab {
+	return BasePosTab{AnchorString: anchor, AnchorUnits: "pixels", AnchorXOffset: "0", AnchorYOffset: "0"}
+}
+
+// SignHere returns a SignHereTab for the recipient anchored to each occurrence
+// of anchor.  An empty docId anchors the tab in all documents.
+func SignHere(recipientId, docId, anchor string) SignHereTab {
+	return SignHereTab{
+		BaseTab:         BaseTab{DocumentID: docId},
+		BasePosTab:      AnchorPos(anchor),
+		BaseTemplateTab: BaseTemplateTab{RecipientID: recipientId},
+	}
+}
+
+// Text returns a TextTab for the recipient anchored to each occurrence
+// of anchor.  An empty docId anchors the tab in all documents.
+func Text(recipientId, docId, anchor, label, value string) TextTab {
+	return TextTab{
+		BaseTab:         BaseTab{DocumentID: docId, TabLabel: label},
+		BasePosTab:      AnchorPos(anchor),
+		BaseTemplateTab: BaseTemplateTab{RecipientID: recipientId},
+		Value:           value,
+	}
+}
+
 type ValueTab interface {
 	NmVal() NmVal
 }
@@ -269,23 +443,56 @@ type FormulaTab struct {
 	BasePosTab
 	BaseStyleTab
 	BaseTemplateTab
-	ConcealValueOnDocument DSBool `json:"concealValueOnDocument,omitempty"`
-	DisableAutoSize        DSBool `json:"disableAutoSize,omitempty"`
-	Formula                string `json:"formula,omitempty"`
-	Height                 int    `json:"height,omitempty"`
-	IsPaymentAmount        DSBool `json:"isPaymentAmount,omitempty"`
-	Locked                 DSBool `json:"locked"`
-	MergeFieldXml          string `json:"mergeFieldXml,omitempty"`
-	Required               DSBool `json:"required"`
-	RoundDecimalPlaces     string `json:"roundDecimalPlaces,omitempty"`
-	Value                  string `json:"value,omitempty"`
-	Width                  int    `json:"width,omitempty"`
+	ConcealValueOnDocument DSBool          `json:"concealValueOnDocument,omitempty"`
+	DisableAutoSize        DSBool          `json:"disableAutoSize,omitempty"`
+	Formula                string          `json:"formula,omitempty"`
+	Height                 int             `json:"height,omitempty"`
+	IsPaymentAmount        DSBool          `json:"isPaymentAmount,omitempty"`
+	PaymentDetails         *PaymentDetails `json:"paymentDetails,omitempty"`
+	Locked                 DSBool          `json:"locked"`
+	MergeFieldXml          string          `json:"mergeFieldXml,omitempty"`
+	Required               DSBool          `json:"required"`
+	RoundDecimalPlaces     string          `json:"roundDecimalPlaces,omitempty"`
+	Value                  string          `json:"value,omitempty"`
+	Width                  int             `json:"width,omitempty"`
 }
 
 func (f FormulaTab) NmVal() NmVal {
 	return NmVal{Name: f.TabLabel, Value: f.Value}
 }
 
+// PaymentDetails describes the payment collected by a FormulaTab
+// with IsPaymentAmount set.
+type PaymentDetails struct {
+	ChargeId           string            `json:"chargeId,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	Total              *Money            `json:"total,omitempty"`
+	CurrencyCode       string            `json:"currencyCode,omitempty"`
+	GatewayAccountId   string            `json:"gatewayAccountId,omitempty"`
+	GatewayName        string            `json:"gatewayName,omitempty"`
+	GatewayDisplayName string            `json:"gatewayDisplayName,omitempty"`
+	PaymentOption      string            `json:"paymentOption,omitempty"`
+	PaymentSourceId    string            `json:"paymentSourceId,omitempty"`
+	CustomerId         string            `json:"customerId,omitempty"`
+	LineItems          []PaymentLineItem `json:"lineItems,omitempty"`
+}
+
+// Money is an amount of a currency.
+type Money struct {
+	AmountInBaseUnit string `json:"amountInBaseUnit,omitempty"`
+	Currency         string `json:"currency,omitempty"`
+	DisplayAmount    string `json:"displayAmount,omitempty"`
+}
+
+// PaymentLineItem describes an item of a payment.  AmountReference is the
+// TabLabel of the tab containing the item's amount.
+type PaymentLineItem struct {
+	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	ItemCode        string `json:"itemCode,omitempty"`
+	AmountReference string `json:"amountReference,omitempty"`
+}
+
 type FullNameTab struct {
 	BaseTab
 	BasePosTab
@@ -329,7 +536,7 @@ type ListTab struct {
 	MergeFieldXml                   string     `json:"mergeFieldXml,omitempty"`
 	Required                        DSBool     `json:"required"`
 	RequireInitialOnSharedTabChange DSBool     `json:"requireInitialOnSharedTabChange,omitempty"`
-	senderRequired                  DSBool     `json:"senderRequired,omitempty,omitempty"`
+	SenderRequired                  DSBool     `json:"senderRequired,omitempty"`
 	Shared                          DSBool     `json:"shared,omitempty"`
 	Value                           string     `json:"value,omitempty"`
 	Width                           int        `json:"width,omitempty"`
@@ -470,7 +677,7 @@ type TextTab struct {
 	MergeFieldXml                   string `json:"mergeFieldXml,omitempty"`
 	Required                        DSBool `json:"required"`
 	RequireInitialOnSharedTabChange DSBool `json:"requireInitialOnSharedTabChange,omitempty"`
-	senderRequired                  DSBool `json:"senderRequired,omitempty"`
+	SenderRequired                  DSBool `json:"senderRequired,omitempty"`
 	Shared                          DSBool `json:"shared,omitempty"`
 	ValidationMessage               string `json:"validationMessage,omitempty"`
 	ValidationPattern               string `json:"validationPattern,omitempty"`
@@ -495,7 +702,7 @@ type TitleTab struct {
 	MergeFieldXml                   string `json:"mergeFieldXml,omitempty"`
 	Required                        DSBool `json:"required"`
 	RequireInitialOnSharedTabChange DSBool `json:"requireInitialOnSharedTabChange,omitempty"`
-	senderRequired                  DSBool `json:"senderRequired,omitempty"`
+	SenderRequired                  DSBool `json:"senderRequired,omitempty"`
 	Shared                          DSBool `json:"shared,omitempty"`
 	ValidationMessage               string `json:"validationMessage,omitempty"`
 	ValidationPattern               string `json:"validationPattern,omitempty"`